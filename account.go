@@ -1,39 +1,42 @@
-package main
+package lichess
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 // User struct represents user account in lichess.org
 // Some fileds may not be initialized
 type User struct {
-	ID              string                 `json:"id"`
-	Username        string                 `json:"username"`
-	Online          bool                   `json:"online"`
-	Performances    map[string]Performance `json:"perfs"`
-	CreatedAt       int64                  `json:"createdAt"`
-	Disabled        bool                   `json:"disabled"`
-	TOSViolation    bool                   `json:"tosViolation"`
-	Booster         bool                   `json:"booster"`
-	Profile         Profile                `json:"profile"`
-	SeenAt          int64                  `json:"seenAt"`
-	Patron          bool                   `json:"patron"`
-	PlayTime        PlayTime               `json:"playTime"`
-	Language        string                 `json:"language"`
-	Title           string                 `json:"title"`
-	URL             string                 `json:"url"`
-	Playing         string                 `json:"playing"`
-	NumberFollowing int                    `json:"nbFollowing"`
-	NumberFollowers int                    `json:"nbFollowers"`
-	CompletionRate  int                    `json:"completionRate"`
-	Count           StatsCount             `json:"count"`
-	Streaming       bool                   `json:"streaming"`
-	Followable      bool                   `json:"followable"`
-	Following       bool                   `json:"following"`
-	Blocking        bool                   `json:"blocking"`
-	FollowsYou      bool                   `json:"followsYou"`
+	ID              string                   `json:"id"`
+	Username        string                   `json:"username"`
+	Online          bool                     `json:"online"`
+	Performances    map[PerfType]Performance `json:"perfs"`
+	CreatedAt       Timestamp                `json:"createdAt"`
+	Disabled        bool                     `json:"disabled"`
+	TOSViolation    bool                     `json:"tosViolation"`
+	Booster         bool                     `json:"booster"`
+	Profile         Profile                  `json:"profile"`
+	SeenAt          Timestamp                `json:"seenAt"`
+	Patron          bool                     `json:"patron"`
+	PlayTime        PlayTime                 `json:"playTime"`
+	Language        string                   `json:"language"`
+	Title           string                   `json:"title"`
+	URL             string                   `json:"url"`
+	Playing         string                   `json:"playing"`
+	NumberFollowing int                      `json:"nbFollowing"`
+	NumberFollowers int                      `json:"nbFollowers"`
+	CompletionRate  int                      `json:"completionRate"`
+	Count           StatsCount               `json:"count"`
+	Streaming       bool                     `json:"streaming"`
+	Followable      bool                     `json:"followable"`
+	Following       bool                     `json:"following"`
+	Blocking        bool                     `json:"blocking"`
+	FollowsYou      bool                     `json:"followsYou"`
+	JoinedTeamAt    Timestamp                `json:"joinedTeamAt"` // Only in team members
 }
 
 // Performance struct stores user performance in one category
@@ -45,6 +48,13 @@ type Performance struct {
 	Prov            bool `json:"prov"` // IDK what this field means
 }
 
+// PerfFor returns user performance in perf.
+// Reports false if user has no rating in perf or it was not requested
+func (u *User) PerfFor(perf PerfType) (Performance, bool) {
+	p, ok := u.Performances[perf]
+	return p, ok
+}
+
 // Profile struct adds additional information about user
 type Profile struct {
 	Country    string `json:"country"`
@@ -121,13 +131,14 @@ type Preferences struct {
 }
 
 // GetMyProfile returns information about logged user
-func (l *LichessAPI) GetMyProfile() (*User, error) {
+func (l *LichessAPI) GetMyProfile(ctx context.Context) (*User, error) {
 	params := &reqParams{
+		name:        "accountProfile",
 		requestType: http.MethodGet,
 		endpoint:    l.endpoint.accountProfile,
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -144,13 +155,15 @@ func (l *LichessAPI) GetMyProfile() (*User, error) {
 }
 
 // GetMyEmail returns user's email
-func (l *LichessAPI) GetMyEmail() (string, error) {
+func (l *LichessAPI) GetMyEmail(ctx context.Context) (string, error) {
 	params := &reqParams{
+		name:        "accountEmail",
 		requestType: http.MethodGet,
 		endpoint:    l.endpoint.accountEmail,
+		scopes:      []string{ScopeEmailRead},
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return "", err
 	}
@@ -172,13 +185,15 @@ func (l *LichessAPI) GetMyEmail() (string, error) {
 }
 
 // GetMyPreferences returns user's preferences
-func (l *LichessAPI) GetMyPreferences() (*Preferences, error) {
+func (l *LichessAPI) GetMyPreferences(ctx context.Context) (*Preferences, error) {
 	params := &reqParams{
+		name:        "accountPreferences",
 		requestType: http.MethodGet,
 		endpoint:    l.endpoint.accountPreferences,
+		scopes:      []string{ScopePreferenceRead},
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -200,13 +215,15 @@ func (l *LichessAPI) GetMyPreferences() (*Preferences, error) {
 }
 
 // GetMyKidModeStatus returns user's kid mode status
-func (l *LichessAPI) GetMyKidModeStatus() (bool, error) {
+func (l *LichessAPI) GetMyKidModeStatus(ctx context.Context) (bool, error) {
 	params := &reqParams{
+		name:        "accountKidModeStatus",
 		requestType: http.MethodGet,
 		endpoint:    l.endpoint.accountKidModeStatus,
+		scopes:      []string{ScopePreferenceRead},
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return false, err
 	}
@@ -229,14 +246,16 @@ func (l *LichessAPI) GetMyKidModeStatus() (bool, error) {
 
 // SetMyKidModeStatus sets user's kid mode status.
 // Returns true on success
-func (l *LichessAPI) SetMyKidModeStatus(newStatus bool) (bool, error) {
+func (l *LichessAPI) SetMyKidModeStatus(ctx context.Context, newStatus bool) (bool, error) {
 	params := &reqParams{
+		name:        "accountKidModeStatus",
 		requestType: http.MethodPost,
 		endpoint:    l.endpoint.accountKidModeStatus,
-		data:        []byte(fmt.Sprintf("v=%v", newStatus)),
+		form:        url.Values{"v": {strconv.FormatBool(newStatus)}},
+		scopes:      []string{ScopePreferenceWrite},
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return false, err
 	}
@@ -1,6 +1,7 @@
-package main
+package lichess
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -104,7 +105,7 @@ func Test_GetMyProfile(t *testing.T) {
 				ID:       "georges",
 				Username: "Georges",
 				Online:   true,
-				Performances: map[string]Performance{
+				Performances: map[PerfType]Performance{
 					"blitz": {
 						Games:           2945,
 						Rating:          1609,
@@ -120,7 +121,7 @@ func Test_GetMyProfile(t *testing.T) {
 						Prov:            true,
 					},
 				},
-				CreatedAt:    1290415680000,
+				CreatedAt:    Timestamp{unixMilli(1290415680000)},
 				Disabled:     false,
 				TOSViolation: false,
 				Booster:      false,
@@ -135,7 +136,7 @@ func Test_GetMyProfile(t *testing.T) {
 					EcfRating:  1500,
 					Links:      "github.com/ornicar\r\ntwitter.com/ornicar",
 				},
-				SeenAt: 1522636452014,
+				SeenAt: Timestamp{unixMilli(1522636452014)},
 				Patron: true,
 				PlayTime: PlayTime{
 					Total: 3296897,
@@ -190,10 +191,17 @@ func Test_GetMyProfile(t *testing.T) {
 			})
 			lapi.endpoint.accountProfile = server.URL
 
-			user, err := lapi.GetMyProfile()
+			user, err := lapi.GetMyProfile(context.Background())
 
 			assert.Equal(tt.want, *user)
 			assert.Equal(tt.wantErr, err)
+
+			blitz, ok := user.PerfFor(PerfBlitz)
+			assert.True(ok)
+			assert.Equal(tt.want.Performances[PerfBlitz], blitz)
+
+			_, ok = user.PerfFor(PerfHorde)
+			assert.False(ok)
 		})
 	}
 }
@@ -244,7 +252,7 @@ func Test_GetMyEmail(t *testing.T) {
 			})
 			lapi.endpoint.accountEmail = server.URL
 
-			mail, err := lapi.GetMyEmail()
+			mail, err := lapi.GetMyEmail(context.Background())
 
 			assert.Equal(tt.want, mail)
 			assert.Equal(tt.wantErr, err)
@@ -298,7 +306,7 @@ func Test_GetMyKidStatus(t *testing.T) {
 			})
 			lapi.endpoint.accountKidModeStatus = server.URL
 
-			kid, err := lapi.GetMyKidModeStatus()
+			kid, err := lapi.GetMyKidModeStatus(context.Background())
 
 			assert.Equal(tt.want, kid)
 			assert.Equal(tt.wantErr, err)
@@ -342,6 +350,8 @@ func Test_SetMyKidStatus(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 				assert.Equal(tt.params.requestType, req.Method)
 
+				assert.Equal("application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+
 				body, _ := ioutil.ReadAll(req.Body)
 				assert.Equal(tt.params.requestBody, string(body))
 
@@ -354,7 +364,7 @@ func Test_SetMyKidStatus(t *testing.T) {
 			})
 			lapi.endpoint.accountKidModeStatus = server.URL
 
-			kid, err := lapi.SetMyKidModeStatus(tt.args)
+			kid, err := lapi.SetMyKidModeStatus(context.Background(), tt.args)
 
 			assert.Equal(tt.want, kid)
 			assert.Equal(tt.wantErr, err)
@@ -0,0 +1,118 @@
+package lichess
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Activity stores user activity on lichess.org
+type Activity struct {
+	Interval            Interval                `json:"interval"`
+	Games               map[string]GameActivity `json:"games"`
+	Puzzles             GameActivity            `json:"puzzles"`
+	Storm               PuzzleRunActivity       `json:"storm"`
+	Racer               PuzzleRunActivity       `json:"racer"`
+	Streak              PuzzleRunActivity       `json:"streak"`
+	Tournaments         TournamentActivity      `json:"tournaments"`
+	Swisses             []SwissActivity         `json:"swisses"`
+	Simuls              []SimulActivity         `json:"simuls"`
+	Studies             []StudyActivity         `json:"studies"`
+	Practices           []Practice              `json:"practice"`
+	CorrespondenceMoves CorrespondenceMoves     `json:"correspondenceMoves"`
+	CorrespondenceEnds  CorrespondenceEnds      `json:"correspondenceEnds"`
+	Follows             Follows                 `json:"follows"`
+	Teams               []TeamActivity          `json:"teams"`
+	Posts               []Topic                 `json:"posts"`
+	Patron              PatronActivity          `json:"patron"`
+	Stream              bool                    `json:"stream"` // User streamed during interval
+
+	// Extra stores activity kinds unknown to client by their key
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// PuzzleRunActivity stores puzzle storm, racer or streak runs
+type PuzzleRunActivity struct {
+	Runs  int `json:"runs"`
+	Score int `json:"score"` // Best score
+}
+
+// SwissActivity stores user's result in swiss tournament
+type SwissActivity struct {
+	Swiss SwissTournament `json:"swiss"`
+	Rank  int             `json:"rank"`
+}
+
+// SwissTournament identifies swiss tournament
+type SwissTournament struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SimulActivity stores simul hosted or played by user
+type SimulActivity struct {
+	ID       string       `json:"id"`
+	Name     string       `json:"name"`
+	IsHost   bool         `json:"isHost"`
+	Variants []string     `json:"variants"`
+	Score    GameActivity `json:"score"`
+}
+
+// StudyActivity identifies study created by user
+type StudyActivity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PatronActivity stores patron months purchased by user
+type PatronActivity struct {
+	Months int `json:"months"`
+}
+
+// activityKeys are json keys decoded into Activity fields
+var activityKeys = jsonKeys(reflect.TypeOf(Activity{}))
+
+// UnmarshalJSON for Activity struct
+func (a *Activity) UnmarshalJSON(data []byte) error {
+	type activity Activity
+
+	var v activity
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*a = Activity(v)
+	a.Extra = nil
+
+	for key, value := range fields {
+		if activityKeys[key] {
+			continue
+		}
+
+		if a.Extra == nil {
+			a.Extra = make(map[string]json.RawMessage)
+		}
+		a.Extra[key] = value
+	}
+
+	return nil
+}
+
+// jsonKeys returns json keys of struct fields
+func jsonKeys(t reflect.Type) map[string]bool {
+	keys := make(map[string]bool)
+
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+
+	return keys
+}
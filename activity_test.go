@@ -0,0 +1,103 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetUserActivity(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(http.MethodGet, req.Method)
+		assert.Equal("/georges", req.URL.Path)
+
+		rw.Write([]byte(`[{
+			"interval": {"start": 1600000000000, "end": 1600086400000},
+			"games": {"blitz": {"win": 3, "loss": 1, "draw": 0, "rp": {"before": 1500, "after": 1520}}},
+			"storm": {"runs": 4, "score": 31},
+			"racer": {"runs": 2, "score": 50},
+			"streak": {"runs": 1, "score": 12},
+			"swisses": [{"swiss": {"id": "sw1", "name": "Weekly Swiss"}, "rank": 3}],
+			"simuls": [{
+				"id": "si1",
+				"name": "Club simul",
+				"isHost": true,
+				"variants": ["standard"],
+				"score": {"win": 10, "loss": 1, "draw": 2}
+			}],
+			"studies": [{"id": "st1", "name": "Endgames"}],
+			"follows": {"in": {"ids": ["user1"]}, "out": {"ids": ["user2", "user3"]}},
+			"teams": [{"url": "https://lichess.org/team/coders", "name": "Coders"}],
+			"patron": {"months": 1},
+			"stream": true,
+			"puzzleRush": {"runs": 9},
+			"broadcasts": [{"id": "br1"}]
+		}]`))
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "",
+		Client: server.Client(),
+	})
+	lapi.endpoint.userActivity = server.URL + "/%s"
+
+	activity, err := lapi.GetUserActivity(context.Background(), "georges")
+
+	assert.NoError(err)
+	assert.Equal([]Activity{
+		{
+			Interval: Interval{
+				Start: Timestamp{unixMilli(1600000000000)},
+				End:   Timestamp{unixMilli(1600086400000)},
+			},
+			Games: map[string]GameActivity{
+				"blitz": {Win: 3, Loss: 1, Rp: RatingChange{Before: 1500, After: 1520}},
+			},
+			Storm:  PuzzleRunActivity{Runs: 4, Score: 31},
+			Racer:  PuzzleRunActivity{Runs: 2, Score: 50},
+			Streak: PuzzleRunActivity{Runs: 1, Score: 12},
+			Swisses: []SwissActivity{
+				{Swiss: SwissTournament{ID: "sw1", Name: "Weekly Swiss"}, Rank: 3},
+			},
+			Simuls: []SimulActivity{
+				{
+					ID:       "si1",
+					Name:     "Club simul",
+					IsHost:   true,
+					Variants: []string{"standard"},
+					Score:    GameActivity{Win: 10, Loss: 1, Draw: 2},
+				},
+			},
+			Studies: []StudyActivity{{ID: "st1", Name: "Endgames"}},
+			Follows: Follows{
+				In:  []string{"user1"},
+				Out: []string{"user2", "user3"},
+			},
+			Teams:  []TeamActivity{{URL: "https://lichess.org/team/coders", Name: "Coders"}},
+			Patron: PatronActivity{Months: 1},
+			Stream: true,
+			Extra: map[string]json.RawMessage{
+				"puzzleRush": json.RawMessage(`{"runs": 9}`),
+				"broadcasts": json.RawMessage(`[{"id": "br1"}]`),
+			},
+		},
+	}, activity)
+}
+
+func Test_ActivityWithoutExtra(t *testing.T) {
+	assert := assert.New(t)
+
+	var activity Activity
+	err := json.Unmarshal([]byte(`{"stream": true}`), &activity)
+
+	assert.NoError(err)
+	assert.True(activity.Stream)
+	assert.Nil(activity.Extra)
+}
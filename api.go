@@ -1,48 +1,159 @@
-package main
+// Package lichess provides client for lichess.org API
+package lichess
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 // Config stores account configuration
 type Config struct {
+	// Token is personal or OAuth access token.
+	// Requests are sent anonymously if it is empty
 	Token  string
 	Client *http.Client
+
+	// Host is base URL of lichess.org, DefaultHost is used if empty
+	Host string
+	// ExplorerHost is base URL of opening explorer, DefaultExplorerHost is used if empty
+	ExplorerHost string
+	// TablebaseHost is base URL of tablebase, DefaultTablebaseHost is used if empty
+	TablebaseHost string
+	// EngineHost is base URL of external engine service, DefaultEngineHost is used if empty
+	EngineHost string
+
+	// UserAgent is sent with every request, DefaultUserAgent is used if empty.
+	// Lichess asks to identify application and contact in it
+	UserAgent string
+
+	// CheckScopes makes methods verify token scopes before calling
+	// protected endpoints and return MissingScopeError instead
+	CheckScopes bool
+
+	// Interceptors observe every request, see LichessAPI.Use
+	Interceptors []Interceptor
+
+	// RateLimitRetries is number of transparent retries of request
+	// that received 429 response. Zero disables retrying
+	RateLimitRetries int
 }
 
+// DefaultUserAgent is sent when Config.UserAgent is empty
+const DefaultUserAgent = "lichess-go-api"
+
 // LichessAPI represents struct that handles api calls
 type LichessAPI struct {
-	bearerToken string
-	client      *http.Client
-	endpoint    *serviceEndpoint
+	token            string
+	userAgent        string
+	client           *http.Client
+	endpoint         *serviceEndpoint
+	limiter          *rateLimiter
+	scopes           *scopeCache
+	interceptors     []Interceptor
+	rateLimitRetries int
 }
 
 // NewLichessAPI creates LichessAPI struct from config
 func NewLichessAPI(cfg Config) *LichessAPI {
 	apiController := &LichessAPI{
-		bearerToken: fmt.Sprintf("Bearer %s", cfg.Token),
-		client:      cfg.Client,
-		endpoint:    newServiceEndpoint(),
+		token:            cfg.Token,
+		userAgent:        cfg.UserAgent,
+		client:           cfg.Client,
+		endpoint:         newServiceEndpoint(cfg),
+		limiter:          newRateLimiter(),
+		scopes:           newScopeCache(cfg.CheckScopes),
+		interceptors:     cfg.Interceptors,
+		rateLimitRetries: cfg.RateLimitRetries,
 	}
 
 	if apiController.client == nil {
 		apiController.client = &http.Client{}
 	}
 
+	if apiController.userAgent == "" {
+		apiController.userAgent = DefaultUserAgent
+	}
+
 	return apiController
 }
 
-// request is called when requesting data from lichess.org
-func (l *LichessAPI) request(par *reqParams) (*http.Response, error) {
-	req, err := http.NewRequest(par.requestType, par.endpoint, bytes.NewBuffer(par.data))
+// request is called when requesting data from lichess.org.
+// Request is aborted when ctx is cancelled.
+// Requests are paused while token is throttled after 429 response
+func (l *LichessAPI) request(ctx context.Context, par *reqParams) (*http.Response, error) {
+	token := l.tokenFor(ctx)
+
+	if err := l.checkScopes(ctx, token, par); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := l.limiter.wait(ctx, token); err != nil {
+			return nil, err
+		}
+
+		info := &RequestInfo{
+			Endpoint: par.name,
+			Method:   par.requestType,
+			Attempt:  attempt,
+		}
+
+		start := time.Now()
+		resp, err := l.do(ctx, token, par, info)
+
+		result := &ResponseInfo{
+			RequestInfo: *info,
+			Duration:    time.Since(start),
+			Err:         err,
+		}
+
+		var apiErr *APIError
+		switch {
+		case resp != nil:
+			result.StatusCode = resp.StatusCode
+		case errors.As(err, &apiErr):
+			result.StatusCode = apiErr.StatusCode
+		}
+
+		if IsRateLimited(err) {
+			result.ThrottledUntil = l.limiter.throttle(token, apiErr.RetryAfter)
+			apiErr.RetryAfter = time.Until(result.ThrottledUntil)
+		}
+
+		l.afterResponse(ctx, result)
+
+		if !IsRateLimited(err) || attempt >= l.rateLimitRetries {
+			return resp, err
+		}
+	}
+}
+
+// do sends single request to lichess.org
+func (l *LichessAPI) do(ctx context.Context, token string, par *reqParams, info *RequestInfo) (*http.Response, error) {
+	data := par.data
+	if par.form != nil {
+		data = []byte(par.form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, par.requestType, par.endpoint, bytes.NewBuffer(data))
 
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Authorization", l.bearerToken)
+	if par.form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	if token != "" {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	req.Header.Set("User-Agent", l.userAgent)
 
 	if par.header != nil {
 		for k, v := range par.header {
@@ -58,18 +169,29 @@ func (l *LichessAPI) request(par *reqParams) (*http.Response, error) {
 		req.URL.RawQuery = q.Encode()
 	}
 
+	info.Request = req
+	l.beforeRequest(ctx, info)
+
 	resp, err := l.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, newAPIError(par, resp)
+	}
+
 	return resp, nil
 }
 
 type reqParams struct {
+	name        string
 	requestType string
 	endpoint    string
 	header      map[string]string
 	query       map[string]string
 	data        []byte
+	form        url.Values // Sent url-encoded instead of data
+	scopes      []string
 }
@@ -0,0 +1,355 @@
+package lichess
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_APIError(t *testing.T) {
+	assert := assert.New(t)
+
+	type params struct {
+		status   int
+		header   map[string]string
+		response string
+	}
+	tests := []struct {
+		name    string
+		params  params
+		want    *APIError
+		checker func(error) bool
+	}{
+		{
+			name: "Unknown user",
+			params: params{
+				status:   http.StatusNotFound,
+				response: `{"error": "Not found"}`,
+			},
+			want: &APIError{
+				StatusCode: http.StatusNotFound,
+				Message:    "Not found",
+			},
+			checker: IsNotFound,
+		},
+		{
+			name: "Bad token",
+			params: params{
+				status:   http.StatusUnauthorized,
+				response: `{"error": "No such token"}`,
+			},
+			want: &APIError{
+				StatusCode: http.StatusUnauthorized,
+				Message:    "No such token",
+			},
+			checker: IsUnauthorized,
+		},
+		{
+			name: "Validation error",
+			params: params{
+				status:   http.StatusBadRequest,
+				response: `{"error": {"v": ["required"]}}`,
+			},
+			want: &APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    `{"v": ["required"]}`,
+			},
+			checker: IsBadRequest,
+		},
+		{
+			name: "Plain text error with retry hint",
+			params: params{
+				status: http.StatusServiceUnavailable,
+				header: map[string]string{
+					"Retry-After": "30",
+				},
+				response: "Service unavailable\n",
+			},
+			want: &APIError{
+				StatusCode: http.StatusServiceUnavailable,
+				Message:    "Service unavailable",
+				RetryAfter: 30 * time.Second,
+			},
+			checker: func(err error) bool {
+				return !IsNotFound(err) && !IsUnauthorized(err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				for k, v := range tt.params.header {
+					rw.Header().Set(k, v)
+				}
+				rw.WriteHeader(tt.params.status)
+				rw.Write([]byte(tt.params.response))
+			}))
+
+			lapi := NewLichessAPI(Config{
+				Token:  "",
+				Client: server.Client(),
+			})
+			lapi.endpoint.userProfile = server.URL + "/%s"
+
+			user, err := lapi.GetUser(context.Background(), "unknown")
+
+			tt.want.Endpoint = "userProfile"
+			tt.want.URL = server.URL + "/unknown"
+
+			assert.Nil(user)
+			assert.Equal(tt.want, err)
+			assert.True(tt.checker(err))
+		})
+	}
+}
+
+func Test_RequestContext(t *testing.T) {
+	assert := assert.New(t)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-release:
+		case <-req.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "",
+		Client: server.Client(),
+	})
+	lapi.endpoint.userProfile = server.URL + "/%s"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	user, err := lapi.GetUser(ctx, "georges")
+
+	assert.Nil(user)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func Test_RateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name         string
+		retries      int
+		wantRequests int
+		wantErr      bool
+	}{
+		{
+			name:         "Throttle without retry",
+			retries:      0,
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "Retry after backoff",
+			retries:      1,
+			wantRequests: 2,
+			wantErr:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				requests++
+				if requests == 1 {
+					rw.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+
+				rw.Write([]byte(`{"id": "georges"}`))
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:            "",
+				Client:           server.Client(),
+				RateLimitRetries: tt.retries,
+			})
+			lapi.endpoint.userProfile = server.URL + "/%s"
+			lapi.limiter.backoff = 20 * time.Millisecond
+
+			start := time.Now()
+			user, err := lapi.GetUser(context.Background(), "georges")
+
+			assert.Equal(tt.wantRequests, requests)
+			if tt.wantErr {
+				assert.Nil(user)
+				assert.True(IsRateLimited(err))
+				assert.True(lapi.IsThrottled())
+
+				time.Sleep(lapi.limiter.backoff)
+				assert.False(lapi.IsThrottled())
+			} else {
+				assert.NoError(err)
+				assert.Equal("georges", user.ID)
+				assert.True(time.Since(start) >= lapi.limiter.backoff)
+				assert.False(lapi.IsThrottled())
+			}
+		})
+	}
+}
+
+func Test_ConfigHosts(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name          string
+		userAgent     string
+		wantUserAgent string
+	}{
+		{
+			name:          "Default user agent",
+			userAgent:     "",
+			wantUserAgent: DefaultUserAgent,
+		},
+		{
+			name:          "Custom user agent",
+			userAgent:     "club-bot/1.0 (admin@example.org)",
+			wantUserAgent: "club-bot/1.0 (admin@example.org)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal("/api/user/georges", req.URL.Path)
+				assert.Equal(tt.wantUserAgent, req.UserAgent())
+
+				rw.Write([]byte(`{"id": "georges"}`))
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:     "",
+				Client:    server.Client(),
+				Host:      server.URL + "/",
+				UserAgent: tt.userAgent,
+			})
+
+			user, err := lapi.GetUser(context.Background(), "georges")
+
+			assert.NoError(err)
+			assert.Equal("georges", user.ID)
+		})
+	}
+}
+
+func Test_DefaultHosts(t *testing.T) {
+	assert := assert.New(t)
+
+	endpoint := newServiceEndpoint(Config{})
+
+	assert.Equal(DefaultHost, endpoint.host)
+	assert.Equal(DefaultExplorerHost, endpoint.explorerHost)
+	assert.Equal(DefaultTablebaseHost, endpoint.tablebaseHost)
+	assert.Equal(DefaultEngineHost, endpoint.engineHost)
+	assert.Equal("https://lichess.org/api/account", endpoint.accountProfile)
+}
+
+func Test_Authorization(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name       string
+		token      string
+		ctx        context.Context
+		wantHeader string
+	}{
+		{
+			name:       "Anonymous client",
+			token:      "",
+			ctx:        context.Background(),
+			wantHeader: "",
+		},
+		{
+			name:       "Config token",
+			token:      "lip_club",
+			ctx:        context.Background(),
+			wantHeader: "Bearer lip_club",
+		},
+		{
+			name:       "Per-call token",
+			token:      "lip_club",
+			ctx:        WithToken(context.Background(), "lip_member"),
+			wantHeader: "Bearer lip_member",
+		},
+		{
+			name:       "Per-call anonymous",
+			token:      "lip_club",
+			ctx:        WithAnonymous(context.Background()),
+			wantHeader: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				_, ok := req.Header["Authorization"]
+				assert.Equal(tt.wantHeader != "", ok)
+				assert.Equal(tt.wantHeader, req.Header.Get("Authorization"))
+
+				rw.Write([]byte(`{"id": "georges"}`))
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:  tt.token,
+				Client: server.Client(),
+				Host:   server.URL,
+			})
+
+			_, err := lapi.GetUser(tt.ctx, "georges")
+
+			assert.NoError(err)
+		})
+	}
+}
+
+func Test_ThrottledUntilFor(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") == "Bearer lip_member" {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		rw.Write([]byte(`{"id": "georges"}`))
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "lip_club",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+
+	member := WithToken(context.Background(), "lip_member")
+	anonymous := WithAnonymous(context.Background())
+
+	_, err := lapi.GetUser(member, "georges")
+	assert.True(IsRateLimited(err))
+
+	assert.True(lapi.IsThrottledFor(member))
+	assert.False(lapi.ThrottledUntilFor(member).IsZero())
+	assert.False(lapi.IsThrottledFor(anonymous))
+	assert.False(lapi.IsThrottledFor(context.Background()))
+	assert.False(lapi.IsThrottled())
+
+	_, err = lapi.GetUser(anonymous, "georges")
+	assert.NoError(err)
+}
@@ -0,0 +1,66 @@
+package lichess
+
+import "strings"
+
+// Maximum number of ids lichess.org accepts in one request
+const (
+	maxUserStatusIDs = 50
+	maxUsersByID     = 300
+)
+
+// chunkIDs splits ids into chunks of at most size elements.
+// Repeated ids are requested once, lichess ids are case insensitive
+func chunkIDs(ids []string, size int) [][]string {
+	var chunks [][]string
+	var chunk []string
+
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		key := strings.ToLower(id)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		chunk = append(chunk, id)
+		if len(chunk) == size {
+			chunks = append(chunks, chunk)
+			chunk = nil
+		}
+	}
+
+	if len(chunk) != 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+// orderByIDs returns positions of found ids in order of requested ids
+// and requested ids that were not found. Repeated ids are reported once
+func orderByIDs(requested, found []string) ([]int, []string) {
+	positions := make(map[string]int, len(found))
+	for pos, id := range found {
+		positions[strings.ToLower(id)] = pos
+	}
+
+	var order []int
+	var missing []string
+
+	seen := make(map[string]bool)
+	for _, id := range requested {
+		key := strings.ToLower(id)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if pos, ok := positions[key]; ok {
+			order = append(order, pos)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	return order, missing
+}
@@ -0,0 +1,108 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BatchUsers(t *testing.T) {
+	assert := assert.New(t)
+
+	// closed accounts are silently omitted by lichess.org
+	closed := map[string]bool{
+		"user7":   true,
+		"user301": true,
+	}
+
+	var ids []string
+	for i := 0; i < 320; i++ {
+		ids = append(ids, fmt.Sprintf("User%d", i))
+	}
+	ids = append(ids, "user5")
+
+	var wantUsers []User
+	for i := 0; i < 320; i++ {
+		id := fmt.Sprintf("user%d", i)
+		if !closed[id] {
+			wantUsers = append(wantUsers, User{ID: id})
+		}
+	}
+	wantMissing := []string{"User7", "User301"}
+
+	tests := []struct {
+		name         string
+		call         func(lapi *LichessAPI) ([]User, []string, error)
+		wantRequests int
+	}{
+		{
+			name: "Get user status",
+			call: func(lapi *LichessAPI) ([]User, []string, error) {
+				statuses, missing, err := lapi.GetUserStatus(context.Background(), nil, ids...)
+
+				var users []User
+				for _, status := range statuses {
+					users = append(users, User{ID: status.ID})
+				}
+
+				return users, missing, err
+			},
+			wantRequests: 7,
+		},
+		{
+			name: "Get users by id",
+			call: func(lapi *LichessAPI) ([]User, []string, error) {
+				return lapi.GetUsesByID(context.Background(), ids...)
+			},
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				requests++
+
+				requested := req.URL.Query().Get("ids")
+				if req.Method == http.MethodPost {
+					body, _ := ioutil.ReadAll(req.Body)
+					requested = string(body)
+				}
+
+				var users []map[string]string
+				// lichess.org does not keep order of requested ids
+				chunk := strings.Split(requested, ",")
+				for i := len(chunk) - 1; i >= 0; i-- {
+					id := strings.ToLower(chunk[i])
+					if !closed[id] {
+						users = append(users, map[string]string{"id": id})
+					}
+				}
+
+				json.NewEncoder(rw).Encode(users)
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:  "",
+				Client: server.Client(),
+				Host:   server.URL,
+			})
+
+			users, missing, err := tt.call(lapi)
+
+			assert.NoError(err)
+			assert.Equal(tt.wantRequests, requests)
+			assert.Equal(wantUsers, users)
+			assert.Equal(wantMissing, missing)
+		})
+	}
+}
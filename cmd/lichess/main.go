@@ -0,0 +1,84 @@
+// Command lichess prints lichess.org data as json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	lichess "github.com/aoyako/lichess-go-api"
+)
+
+const usage = `Usage: lichess [flags] <command> [args]
+
+Commands:
+  me                 print profile of token owner
+  user <username>    print public profile of user
+  team <id>          print members of team
+
+Flags:
+`
+
+func main() {
+	token := flag.String("token", os.Getenv("LICHESS_TOKEN"), "API token, defaults to $LICHESS_TOKEN")
+	host := flag.String("host", "", "lichess host, defaults to "+lichess.DefaultHost)
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	api := lichess.NewLichessAPI(lichess.Config{
+		Token: *token,
+		Host:  *host,
+	})
+
+	if err := run(context.Background(), api, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run executes command given in args
+func run(ctx context.Context, api *lichess.LichessAPI, args []string) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return fmt.Errorf("command is required")
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	switch {
+	case args[0] == "me" && len(args) == 1:
+		user, err := api.GetMyProfile(ctx)
+		if err != nil {
+			return err
+		}
+		return encoder.Encode(user)
+	case args[0] == "user" && len(args) == 2:
+		user, err := api.GetUser(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return encoder.Encode(user)
+	case args[0] == "team" && len(args) == 2:
+		stream, err := api.GetTeamMembers(ctx, args[1], nil)
+		if err != nil {
+			return err
+		}
+		defer stream.Close()
+
+		for stream.Next() {
+			if err := encoder.Encode(stream.Value()); err != nil {
+				return err
+			}
+		}
+		return stream.Err()
+	}
+
+	flag.Usage()
+	return fmt.Errorf("unknown command: %v", args)
+}
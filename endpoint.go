@@ -1,6 +1,25 @@
-package main
+package lichess
+
+import "strings"
+
+// Default hosts of lichess.org services
+const (
+	DefaultHost          = "https://lichess.org"
+	DefaultExplorerHost  = "https://explorer.lichess.ovh"
+	DefaultTablebaseHost = "https://tablebase.lichess.ovh"
+	DefaultEngineHost    = "https://engine.lichess.ovh"
+)
 
 type serviceEndpoint struct {
+	host          string
+	explorerHost  string
+	tablebaseHost string
+	engineHost    string
+
+	oauthAuthorize string
+	oauthToken     string
+	tokenTest      string
+
 	accountProfile       string
 	accountEmail         string
 	accountPreferences   string
@@ -12,27 +31,90 @@ type serviceEndpoint struct {
 	userRatingHistory    string
 	userActivity         string
 	userData             string
-	teamMembers          string
 	userLiveStreaming    string
 	userCrosstable       string
+	userPerfStats        string
+	userNote             string
+	playerAutocomplete   string
+	relFollowing         string
+	relFollow            string
+	relUnfollow          string
+	relBlock             string
+	relUnblock           string
+	teamPage             string
+	team                 string
+	teamAll              string
+	teamSearch           string
+	teamsOfUser          string
+	teamMembers          string
+	teamJoin             string
+	teamQuit             string
+	teamKick             string
+	teamRequests         string
+	teamRequestAccept    string
+	teamRequestDecline   string
+	teamMessageAll       string
 }
 
-func newServiceEndpoint() *serviceEndpoint {
+func newServiceEndpoint(cfg Config) *serviceEndpoint {
+	host := hostOrDefault(cfg.Host, DefaultHost)
+
 	return &serviceEndpoint{
-		accountProfile:       "https://lichess.org/api/account",
-		accountEmail:         "https://lichess.org/api/account/email",
-		accountPreferences:   "https://lichess.org/api/account/preferences",
-		accountKidModeStatus: "https://lichess.org/api/account/kid",
-
-		userStatus:        "https://lichess.org/api/users/status",
-		topAllPlayers:     "https://lichess.org/player",
-		topPlayers:        "https://lichess.org/player/top/%d/%s",
-		userProfile:       "https://lichess.org/api/user/%s",
-		userRatingHistory: "https://lichess.org/api/user/%s/rating-history",
-		userActivity:      "https://lichess.org/api/user/%s/activity",
-		userData:          "https://lichess.org/api/users",
-		teamMembers:       "https://lichess.org/api/team/%s/users",
-		userLiveStreaming: "https://lichess.org/streamer/live",
-		userCrosstable:    "https://lichess.org/api/crosstable/%s/%s",
+		host:          host,
+		explorerHost:  hostOrDefault(cfg.ExplorerHost, DefaultExplorerHost),
+		tablebaseHost: hostOrDefault(cfg.TablebaseHost, DefaultTablebaseHost),
+		engineHost:    hostOrDefault(cfg.EngineHost, DefaultEngineHost),
+
+		oauthAuthorize: host + "/oauth",
+		oauthToken:     host + "/api/token",
+		tokenTest:      host + "/api/token/test",
+
+		accountProfile:       host + "/api/account",
+		accountEmail:         host + "/api/account/email",
+		accountPreferences:   host + "/api/account/preferences",
+		accountKidModeStatus: host + "/api/account/kid",
+
+		userStatus:        host + "/api/users/status",
+		topAllPlayers:     host + "/player",
+		topPlayers:        host + "/player/top/%d/%s",
+		userProfile:       host + "/api/user/%s",
+		userRatingHistory: host + "/api/user/%s/rating-history",
+		userActivity:      host + "/api/user/%s/activity",
+		userData:          host + "/api/users",
+		userLiveStreaming: host + "/api/streamer/live",
+		userCrosstable:    host + "/api/crosstable/%s/%s",
+		userPerfStats:     host + "/api/user/%s/perf/%s",
+		userNote:          host + "/api/user/%s/note",
+
+		playerAutocomplete: host + "/api/player/autocomplete",
+
+		relFollowing: host + "/api/rel/following",
+		relFollow:    host + "/api/rel/follow/%s",
+		relUnfollow:  host + "/api/rel/unfollow/%s",
+		relBlock:     host + "/api/rel/block/%s",
+		relUnblock:   host + "/api/rel/unblock/%s",
+
+		teamPage:           host + "/team/%s",
+		team:               host + "/api/team/%s",
+		teamAll:            host + "/api/team/all",
+		teamSearch:         host + "/api/team/search",
+		teamsOfUser:        host + "/api/team/of/%s",
+		teamMembers:        host + "/api/team/%s/users",
+		teamJoin:           host + "/team/%s/join",
+		teamQuit:           host + "/team/%s/quit",
+		teamKick:           host + "/api/team/%s/kick/%s",
+		teamRequests:       host + "/api/team/%s/requests",
+		teamRequestAccept:  host + "/api/team/%s/request/%s/accept",
+		teamRequestDecline: host + "/api/team/%s/request/%s/decline",
+		teamMessageAll:     host + "/team/%s/pm-all",
 	}
 }
+
+// hostOrDefault returns host without trailing slash or fallback if host is empty
+func hostOrDefault(host, fallback string) string {
+	if host == "" {
+		return fallback
+	}
+
+	return strings.TrimRight(host, "/")
+}
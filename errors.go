@@ -0,0 +1,149 @@
+package lichess
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorBodySize limits how much of an error response is read
+const maxErrorBodySize = 64 << 10
+
+// APIError is returned when lichess.org responds with non-2xx status code
+type APIError struct {
+	StatusCode int
+	Message    string
+	// Endpoint is name of called endpoint, e.g. "userProfile", same as RequestInfo.Endpoint
+	Endpoint string
+	// URL is requested url, it may contain usernames and ids
+	URL        string
+	RetryAfter time.Duration
+}
+
+// Error implements error interface
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("lichess: %s: %d %s", e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	}
+
+	return fmt.Sprintf("lichess: %s: %d %s: %s", e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// newAPIError reads error response and builds APIError from it
+func newAPIError(par *reqParams, resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Endpoint:   par.name,
+		URL:        par.endpoint,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return apiErr
+	}
+
+	apiErr.Message = errorMessage(body)
+
+	return apiErr
+}
+
+// errorMessage extracts message from lichess {"error": ...} body.
+// Falls back to raw body if it is not in expected format
+func errorMessage(body []byte) string {
+	type errorResp struct {
+		Error json.RawMessage `json:"error"`
+	}
+
+	var resp errorResp
+	if err := json.Unmarshal(body, &resp); err != nil || len(resp.Error) == 0 {
+		return strings.TrimSpace(string(body))
+	}
+
+	var message string
+	if err := json.Unmarshal(resp.Error, &message); err == nil {
+		return message
+	}
+
+	return string(resp.Error)
+}
+
+// parseRetryAfter parses Retry-After header given in seconds or as http date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// hasStatus checks if err is APIError with given status code
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == status
+	}
+
+	return false
+}
+
+// IsNotFound reports whether lichess.org responded with 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether lichess.org responded with 401
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether lichess.org responded with 403
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsBadRequest reports whether lichess.org responded with 400
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+// IsRateLimited reports whether lichess.org responded with 429
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// MissingScopeError is returned when token lacks scopes required by endpoint.
+// Only returned when Config.CheckScopes is set
+type MissingScopeError struct {
+	Scopes []string
+	// Endpoint is name of called endpoint, same as APIError.Endpoint
+	Endpoint string
+	URL      string
+}
+
+// Error implements error interface
+func (e *MissingScopeError) Error() string {
+	return fmt.Sprintf("lichess: %s: token is missing scopes: %s", e.Endpoint, strings.Join(e.Scopes, ", "))
+}
+
+// IsMissingScope reports whether token lacks scopes required by endpoint
+func IsMissingScope(err error) bool {
+	var scopeErr *MissingScopeError
+	return errors.As(err, &scopeErr)
+}
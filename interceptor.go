@@ -0,0 +1,85 @@
+package lichess
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes request sent to lichess.org
+type RequestInfo struct {
+	// Endpoint is name of called endpoint, e.g. "userProfile",
+	// full url is available from Request
+	Endpoint string
+	Method   string
+	// Attempt is zero for first request and grows with rate limit retries
+	Attempt int
+	// Request may be modified by BeforeRequest, e.g. to add request id header
+	Request *http.Request
+}
+
+// RedactedHeader returns copy of request header with token hidden
+func (r *RequestInfo) RedactedHeader() http.Header {
+	if r.Request == nil {
+		return http.Header{}
+	}
+
+	header := r.Request.Header.Clone()
+	if header.Get("Authorization") != "" {
+		header.Set("Authorization", "Bearer <redacted>")
+	}
+
+	return header
+}
+
+// ResponseInfo describes result of request sent to lichess.org
+type ResponseInfo struct {
+	RequestInfo
+	// StatusCode is zero if no response was received
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+	// ThrottledUntil is set when response made client pause requests
+	ThrottledUntil time.Time
+}
+
+// Interceptor observes requests made by LichessAPI.
+// Nil functions are skipped
+type Interceptor struct {
+	BeforeRequest func(ctx context.Context, info *RequestInfo)
+	AfterResponse func(ctx context.Context, info *ResponseInfo)
+	OnStreamLine  func(ctx context.Context, endpoint string, line []byte)
+}
+
+// Use appends interceptors to chain, they are called in order added.
+// Must not be called concurrently with requests
+func (l *LichessAPI) Use(interceptors ...Interceptor) {
+	l.interceptors = append(l.interceptors, interceptors...)
+}
+
+// beforeRequest runs BeforeRequest of every interceptor
+func (l *LichessAPI) beforeRequest(ctx context.Context, info *RequestInfo) {
+	for _, i := range l.interceptors {
+		if i.BeforeRequest != nil {
+			i.BeforeRequest(ctx, info)
+		}
+	}
+}
+
+// afterResponse runs AfterResponse of every interceptor
+func (l *LichessAPI) afterResponse(ctx context.Context, info *ResponseInfo) {
+	for _, i := range l.interceptors {
+		if i.AfterResponse != nil {
+			i.AfterResponse(ctx, info)
+		}
+	}
+}
+
+// onStreamLine runs OnStreamLine of every interceptor
+func (l *LichessAPI) onStreamLine(ctx context.Context, endpoint string, line []byte) {
+	for _, i := range l.interceptors {
+		if i.OnStreamLine != nil {
+			i.OnStreamLine(ctx, endpoint, line)
+		}
+	}
+}
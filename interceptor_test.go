@@ -0,0 +1,87 @@
+package lichess
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Interceptor(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal("req-1", req.Header.Get("X-Request-Id"))
+
+		switch req.URL.Path {
+		case "/api/user/georges":
+			rw.Write([]byte(`{"id": "georges"}`))
+		case "/api/team/team/users":
+			rw.Write([]byte("{\"id\": \"user1\"}\n\n{\"id\": \"user2\"}\n"))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var requests []RequestInfo
+	var responses []ResponseInfo
+	var lines []string
+
+	lapi := NewLichessAPI(Config{
+		Token:  "lip_secret",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+	lapi.Use(Interceptor{
+		BeforeRequest: func(ctx context.Context, info *RequestInfo) {
+			info.Request.Header.Set("X-Request-Id", "req-1")
+
+			assert.Equal("Bearer lip_secret", info.Request.Header.Get("Authorization"))
+			assert.Equal("Bearer <redacted>", info.RedactedHeader().Get("Authorization"))
+
+			requests = append(requests, *info)
+		},
+		AfterResponse: func(ctx context.Context, info *ResponseInfo) {
+			responses = append(responses, *info)
+		},
+		OnStreamLine: func(ctx context.Context, endpoint string, line []byte) {
+			assert.Equal("teamMembers", endpoint)
+			lines = append(lines, string(line))
+		},
+	})
+
+	_, err := lapi.GetUser(context.Background(), "georges")
+	assert.NoError(err)
+
+	_, err = lapi.GetUser(context.Background(), "unknown")
+	assert.True(IsNotFound(err))
+
+	stream, err := lapi.GetTeamMembers(context.Background(), "team", nil)
+	assert.NoError(err)
+	for stream.Next() {
+	}
+	stream.Close()
+
+	assert.Len(requests, 3)
+	assert.Len(responses, 3)
+
+	assert.Equal("userProfile", responses[0].Endpoint)
+	assert.Equal(http.MethodGet, responses[0].Method)
+	assert.Equal(http.StatusOK, responses[0].StatusCode)
+	assert.NoError(responses[0].Err)
+
+	assert.Equal("userProfile", responses[1].Endpoint)
+	assert.Equal(http.StatusNotFound, responses[1].StatusCode)
+	assert.True(IsNotFound(responses[1].Err))
+
+	var apiErr *APIError
+	assert.ErrorAs(responses[1].Err, &apiErr)
+	assert.Equal(responses[1].Endpoint, apiErr.Endpoint)
+	assert.Equal(server.URL+"/api/user/unknown", apiErr.URL)
+
+	assert.Equal("teamMembers", responses[2].Endpoint)
+	assert.Equal([]string{`{"id": "user1"}`, `{"id": "user2"}`}, lines)
+}
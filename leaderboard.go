@@ -0,0 +1,166 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+// Leaderboard stores top players of every perf with leaderboard
+type Leaderboard map[PerfType][]LeaderboardEntry
+
+// LeaderboardEntry stores player position in perf leaderboard
+type LeaderboardEntry struct {
+	ID       string
+	Username string
+	Title    string
+	Patron   bool
+	Online   bool
+	Rank     int // Starts from 1
+	Rating   int
+	Progress int // Rating change over last 12 days
+}
+
+// LeaderboardDiff stores changes of perf leaderboard between two snapshots
+type LeaderboardDiff struct {
+	Entered []LeaderboardEntry
+	Dropped []LeaderboardEntry // Entries of previous snapshot
+	Moved   []RankMove         // Ordered by rank change, biggest first
+}
+
+// RankMove stores rank change of player present in both snapshots
+type RankMove struct {
+	Entry LeaderboardEntry // Entry of next snapshot
+	From  int
+	To    int
+}
+
+// Change returns number of ranks gained, negative if player went down
+func (m RankMove) Change() int {
+	return m.From - m.To
+}
+
+// GetAllTop returns top 10 players of every perf with leaderboard
+func (l *LichessAPI) GetAllTop(ctx context.Context) (Leaderboard, error) {
+	type perf struct {
+		Rating   int `json:"rating"`
+		Progress int `json:"progress"`
+	}
+	type player struct {
+		ID       string            `json:"id"`
+		Username string            `json:"username"`
+		Title    string            `json:"title"`
+		Patron   bool              `json:"patron"`
+		Online   bool              `json:"online"`
+		Perfs    map[PerfType]perf `json:"perfs"`
+	}
+
+	var top map[PerfType][]player
+
+	params := &reqParams{
+		name:        "topAllPlayers",
+		requestType: http.MethodGet,
+		endpoint:    l.endpoint.topAllPlayers,
+		header: map[string]string{
+			"Accept": "application/vnd.lichess.v3+json",
+		},
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&top)
+	if err != nil {
+		return nil, err
+	}
+
+	board := make(Leaderboard, len(top))
+	for perfType, players := range top {
+		entries := make([]LeaderboardEntry, len(players))
+
+		for i, p := range players {
+			entries[i] = LeaderboardEntry{
+				ID:       p.ID,
+				Username: p.Username,
+				Title:    p.Title,
+				Patron:   p.Patron,
+				Online:   p.Online,
+				Rank:     i + 1,
+				Rating:   p.Perfs[perfType].Rating,
+				Progress: p.Perfs[perfType].Progress,
+			}
+		}
+
+		board[perfType] = entries
+	}
+
+	return board, nil
+}
+
+// DiffLeaderboards compares two leaderboard snapshots perf by perf.
+// Perfs missing in one of snapshots are compared against empty leaderboard
+func DiffLeaderboards(prev, next Leaderboard) map[PerfType]LeaderboardDiff {
+	result := make(map[PerfType]LeaderboardDiff)
+
+	perfs := make(map[PerfType]bool)
+	for perf := range prev {
+		perfs[perf] = true
+	}
+	for perf := range next {
+		perfs[perf] = true
+	}
+
+	for perf := range perfs {
+		result[perf] = diffEntries(prev[perf], next[perf])
+	}
+
+	return result
+}
+
+// diffEntries compares entries of one perf leaderboard
+func diffEntries(prev, next []LeaderboardEntry) LeaderboardDiff {
+	var diff LeaderboardDiff
+
+	prevRanks := make(map[string]int, len(prev))
+	for _, e := range prev {
+		prevRanks[e.ID] = e.Rank
+	}
+
+	nextIDs := make(map[string]bool, len(next))
+	for _, e := range next {
+		nextIDs[e.ID] = true
+
+		rank, ok := prevRanks[e.ID]
+		switch {
+		case !ok:
+			diff.Entered = append(diff.Entered, e)
+		case rank != e.Rank:
+			diff.Moved = append(diff.Moved, RankMove{Entry: e, From: rank, To: e.Rank})
+		}
+	}
+
+	for _, e := range prev {
+		if !nextIDs[e.ID] {
+			diff.Dropped = append(diff.Dropped, e)
+		}
+	}
+
+	sort.SliceStable(diff.Moved, func(i, j int) bool {
+		return abs(diff.Moved[i].Change()) > abs(diff.Moved[j].Change())
+	})
+
+	return diff
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,113 @@
+package lichess
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetAllTop(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(http.MethodGet, req.Method)
+		assert.Equal("application/vnd.lichess.v3+json", req.Header.Get("Accept"))
+
+		rw.Write([]byte(`{
+			"bullet": [
+				{
+					"id": "bahadirozen",
+					"username": "BahadirOzen",
+					"perfs": {"bullet": {"rating": 3280, "progress": 12}},
+					"title": "FM",
+					"online": true
+				},
+				{
+					"id": "penguingim1",
+					"username": "penguingim1",
+					"perfs": {"bullet": {"rating": 3262, "progress": -8}},
+					"title": "GM",
+					"patron": true
+				}
+			],
+			"blitz": []
+		}`))
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "",
+		Client: server.Client(),
+	})
+	lapi.endpoint.topAllPlayers = server.URL
+
+	board, err := lapi.GetAllTop(context.Background())
+
+	assert.NoError(err)
+	assert.Equal(Leaderboard{
+		PerfBullet: {
+			{
+				ID:       "bahadirozen",
+				Username: "BahadirOzen",
+				Title:    "FM",
+				Online:   true,
+				Rank:     1,
+				Rating:   3280,
+				Progress: 12,
+			},
+			{
+				ID:       "penguingim1",
+				Username: "penguingim1",
+				Title:    "GM",
+				Patron:   true,
+				Rank:     2,
+				Rating:   3262,
+				Progress: -8,
+			},
+		},
+		PerfBlitz: {},
+	}, board)
+}
+
+func Test_DiffLeaderboards(t *testing.T) {
+	assert := assert.New(t)
+
+	entry := func(id string, rank int) LeaderboardEntry {
+		return LeaderboardEntry{ID: id, Rank: rank}
+	}
+
+	prev := Leaderboard{
+		PerfBullet: {entry("user1", 1), entry("user2", 2), entry("user3", 3), entry("user4", 4)},
+		PerfRapid:  {entry("user1", 1)},
+	}
+	next := Leaderboard{
+		PerfBullet: {entry("user4", 1), entry("user1", 2), entry("user5", 3), entry("user2", 4)},
+		PerfBlitz:  {entry("user1", 1)},
+	}
+
+	diff := DiffLeaderboards(prev, next)
+
+	assert.Equal(map[PerfType]LeaderboardDiff{
+		PerfBullet: {
+			Entered: []LeaderboardEntry{entry("user5", 3)},
+			Dropped: []LeaderboardEntry{entry("user3", 3)},
+			Moved: []RankMove{
+				{Entry: entry("user4", 1), From: 4, To: 1},
+				{Entry: entry("user2", 4), From: 2, To: 4},
+				{Entry: entry("user1", 2), From: 1, To: 2},
+			},
+		},
+		PerfBlitz: {
+			Entered: []LeaderboardEntry{entry("user1", 1)},
+		},
+		PerfRapid: {
+			Dropped: []LeaderboardEntry{entry("user1", 1)},
+		},
+	}, diff)
+
+	assert.Equal(3, diff[PerfBullet].Moved[0].Change())
+	assert.Equal(-2, diff[PerfBullet].Moved[1].Change())
+}
@@ -0,0 +1,61 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Note stores private note written by logged in user about other user
+type Note struct {
+	From LightUser `json:"from"`
+	To   LightUser `json:"to"`
+	Text string    `json:"text"`
+	Date Timestamp `json:"date"`
+}
+
+// GetUserNotes returns private notes logged in user wrote about user
+func (l *LichessAPI) GetUserNotes(ctx context.Context, username string) ([]Note, error) {
+	var notes []Note
+
+	params := &reqParams{
+		name:        "userNote",
+		requestType: http.MethodGet,
+		endpoint:    fmt.Sprintf(l.endpoint.userNote, username),
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&notes)
+	if err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+// WriteUserNote adds private note about user, only logged in user can read it
+func (l *LichessAPI) WriteUserNote(ctx context.Context, username, text string) error {
+	params := &reqParams{
+		name:        "userNote",
+		requestType: http.MethodPost,
+		endpoint:    fmt.Sprintf(l.endpoint.userNote, username),
+		form:        url.Values{"text": {text}},
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package lichess
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UserNotes(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal("/api/user/student/note", req.URL.Path)
+
+		switch req.Method {
+		case http.MethodPost:
+			assert.Equal("application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.Equal("text=Weak+endgames+%26+time+trouble", string(body))
+
+			rw.Write([]byte(`{"ok": true}`))
+		case http.MethodGet:
+			rw.Write([]byte(`[{
+				"from": {"id": "coach", "name": "Coach"},
+				"to": {"id": "student", "name": "Student"},
+				"text": "Weak endgames & time trouble",
+				"date": 1522636452014
+			}]`))
+		}
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "lip_coach",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+
+	err := lapi.WriteUserNote(context.Background(), "student", "Weak endgames & time trouble")
+	assert.NoError(err)
+
+	notes, err := lapi.GetUserNotes(context.Background(), "student")
+	assert.NoError(err)
+	assert.Equal([]Note{
+		{
+			From: LightUser{ID: "coach", Name: "Coach"},
+			To:   LightUser{ID: "student", Name: "Student"},
+			Text: "Weak endgames & time trouble",
+			Date: Timestamp{unixMilli(1522636452014)},
+		},
+	}, notes)
+}
@@ -0,0 +1,243 @@
+package lichess
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// shutdownTimeout limits time spent finishing redirect response
+const shutdownTimeout = 5 * time.Second
+
+// OAuthConfig stores OAuth2 application parameters
+type OAuthConfig struct {
+	// ClientID identifies application, lichess.org does not require registration
+	ClientID    string
+	RedirectURI string
+	Scopes      []string
+
+	// Host is base URL of lichess.org, DefaultHost is used if empty
+	Host   string
+	Client *http.Client
+}
+
+// OAuth handles OAuth2 PKCE authorization flow
+type OAuth struct {
+	cfg OAuthConfig
+	api *LichessAPI
+}
+
+// PKCE stores code verifier and its S256 challenge
+type PKCE struct {
+	Verifier  string
+	Challenge string
+}
+
+// Token is OAuth2 access token
+type Token struct {
+	TokenType   string `json:"token_type"`
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// NewOAuth creates OAuth struct from config
+func NewOAuth(cfg OAuthConfig) *OAuth {
+	return &OAuth{
+		cfg: cfg,
+		api: NewLichessAPI(Config{
+			Host:   cfg.Host,
+			Client: cfg.Client,
+		}),
+	}
+}
+
+// NewPKCE generates random code verifier and its challenge
+func NewPKCE() (*PKCE, error) {
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+
+	return &PKCE{
+		Verifier:  verifier,
+		Challenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}, nil
+}
+
+// NewOAuthState generates random state to protect redirect from forgery
+func NewOAuthState() (string, error) {
+	return randomString(16)
+}
+
+// randomString returns n random bytes encoded as url-safe string
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// AuthorizationURL returns url where user grants requested scopes
+func (o *OAuth) AuthorizationURL(state string, pkce *PKCE) string {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {o.cfg.ClientID},
+		"redirect_uri":          {o.cfg.RedirectURI},
+		"code_challenge_method": {"S256"},
+		"code_challenge":        {pkce.Challenge},
+		"state":                 {state},
+	}
+
+	if len(o.cfg.Scopes) != 0 {
+		query.Set("scope", strings.Join(o.cfg.Scopes, " "))
+	}
+
+	return o.api.endpoint.oauthAuthorize + "?" + query.Encode()
+}
+
+// Exchange trades authorization code for access token
+func (o *OAuth) Exchange(ctx context.Context, code string, pkce *PKCE) (*Token, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"code_verifier": {pkce.Verifier},
+		"redirect_uri":  {o.cfg.RedirectURI},
+		"client_id":     {o.cfg.ClientID},
+	}
+
+	params := &reqParams{
+		name:        "oauthToken",
+		requestType: http.MethodPost,
+		endpoint:    o.api.endpoint.oauthToken,
+		form:        form,
+	}
+
+	resp, err := o.api.request(WithAnonymous(ctx), params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var token Token
+	err = json.NewDecoder(resp.Body).Decode(&token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// Config returns Config that makes requests with token
+func (o *OAuth) Config(token *Token) Config {
+	return Config{
+		Token:  token.AccessToken,
+		Host:   o.cfg.Host,
+		Client: o.cfg.Client,
+	}
+}
+
+// ListenForCode serves RedirectURI locally and waits for lichess.org redirect.
+// Intended for command line applications with loopback RedirectURI
+func (o *OAuth) ListenForCode(ctx context.Context, state string) (string, error) {
+	redirect, err := url.Parse(o.cfg.RedirectURI)
+	if err != nil {
+		return "", err
+	}
+
+	listener, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return "", err
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+
+	results := make(chan result, 1)
+
+	path := redirect.Path
+	if path == "" {
+		path = "/"
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != path {
+			http.NotFound(rw, req)
+			return
+		}
+
+		query := req.URL.Query()
+
+		var res result
+		switch {
+		case query.Get("state") != state:
+			res.err = errors.New("OAuth state mismatch")
+		case query.Get("error") != "":
+			res.err = fmt.Errorf("OAuth authorization failed: %s %s", query.Get("error"), query.Get("error_description"))
+		case query.Get("code") == "":
+			res.err = errors.New("OAuth code is missing")
+		default:
+			res.code = query.Get("code")
+		}
+
+		if res.err != nil {
+			http.Error(rw, res.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(rw, "Authorization complete, you may close this window.")
+		}
+
+		select {
+		case results <- res:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-results:
+		return res.code, res.err
+	}
+}
+
+// RevokeToken revokes token used by client
+func (l *LichessAPI) RevokeToken(ctx context.Context) error {
+	params := &reqParams{
+		name:        "oauthToken",
+		requestType: http.MethodDelete,
+		endpoint:    l.endpoint.oauthToken,
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	return nil
+}
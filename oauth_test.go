@@ -0,0 +1,225 @@
+package lichess
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AuthorizationURL(t *testing.T) {
+	assert := assert.New(t)
+
+	oauth := NewOAuth(OAuthConfig{
+		ClientID:    "club-app",
+		RedirectURI: "http://localhost:8080/callback",
+		Scopes:      []string{"email:read", "preference:read"},
+	})
+
+	pkce, err := NewPKCE()
+	assert.NoError(err)
+
+	sum := sha256.Sum256([]byte(pkce.Verifier))
+	assert.Equal(base64.RawURLEncoding.EncodeToString(sum[:]), pkce.Challenge)
+
+	authURL, err := url.Parse(oauth.AuthorizationURL("state1", pkce))
+	assert.NoError(err)
+
+	assert.Equal("https://lichess.org/oauth", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+	assert.Equal(url.Values{
+		"response_type":         {"code"},
+		"client_id":             {"club-app"},
+		"redirect_uri":          {"http://localhost:8080/callback"},
+		"code_challenge_method": {"S256"},
+		"code_challenge":        {pkce.Challenge},
+		"state":                 {"state1"},
+		"scope":                 {"email:read preference:read"},
+	}, authURL.Query())
+}
+
+func Test_Exchange(t *testing.T) {
+	assert := assert.New(t)
+
+	type params struct {
+		status   int
+		response string
+	}
+	tests := []struct {
+		name    string
+		params  params
+		want    *Token
+		wantErr bool
+	}{
+		{
+			name: "Exchange code",
+			params: params{
+				status: http.StatusOK,
+				response: `{
+					"token_type": "Bearer",
+					"access_token": "lio_token",
+					"expires_in": 31536000
+				}`,
+			},
+			want: &Token{
+				TokenType:   "Bearer",
+				AccessToken: "lio_token",
+				ExpiresIn:   31536000,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid code",
+			params: params{
+				status:   http.StatusBadRequest,
+				response: `{"error": "invalid_grant"}`,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(http.MethodPost, req.Method)
+				assert.Equal("/api/token", req.URL.Path)
+				assert.Equal("application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+				assert.Empty(req.Header.Get("Authorization"))
+
+				assert.NoError(req.ParseForm())
+				assert.Equal(url.Values{
+					"grant_type":    {"authorization_code"},
+					"code":          {"code1"},
+					"code_verifier": {"verifier1"},
+					"redirect_uri":  {"http://localhost:8080/callback"},
+					"client_id":     {"club-app"},
+				}, req.PostForm)
+
+				rw.WriteHeader(tt.params.status)
+				rw.Write([]byte(tt.params.response))
+			}))
+			defer server.Close()
+
+			oauth := NewOAuth(OAuthConfig{
+				ClientID:    "club-app",
+				RedirectURI: "http://localhost:8080/callback",
+				Host:        server.URL,
+				Client:      server.Client(),
+			})
+
+			token, err := oauth.Exchange(context.Background(), "code1", &PKCE{Verifier: "verifier1"})
+
+			assert.Equal(tt.want, token)
+			assert.Equal(tt.wantErr, err != nil)
+
+			if token != nil {
+				cfg := oauth.Config(token)
+				assert.Equal("lio_token", cfg.Token)
+				assert.Equal(server.URL, cfg.Host)
+			}
+		})
+	}
+}
+
+func Test_RevokeToken(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(http.MethodDelete, req.Method)
+		assert.Equal("/api/token", req.URL.Path)
+		assert.Equal("Bearer lio_token", req.Header.Get("Authorization"))
+
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "lio_token",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+
+	assert.NoError(lapi.RevokeToken(context.Background()))
+}
+
+func Test_ListenForCode(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		query    string
+		want     string
+		wantErr  bool
+		wantCode int
+	}{
+		{
+			name:     "Code received",
+			query:    "code=code1&state=state1",
+			want:     "code1",
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "State mismatch",
+			query:    "code=code1&state=forged",
+			want:     "",
+			wantErr:  true,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "Access denied",
+			query:    "error=access_denied&state=state1",
+			want:     "",
+			wantErr:  true,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.NoError(err)
+			addr := listener.Addr().String()
+			listener.Close()
+
+			oauth := NewOAuth(OAuthConfig{
+				ClientID:    "club-app",
+				RedirectURI: fmt.Sprintf("http://%s/callback", addr),
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			codes := make(chan string, 1)
+			errs := make(chan error, 1)
+			go func() {
+				code, err := oauth.ListenForCode(ctx, "state1")
+				codes <- code
+				errs <- err
+			}()
+
+			var resp *http.Response
+			for i := 0; i < 50; i++ {
+				resp, err = http.Get(fmt.Sprintf("http://%s/callback?%s", addr, tt.query))
+				if err == nil {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			assert.NoError(err)
+			resp.Body.Close()
+
+			assert.Equal(tt.wantCode, resp.StatusCode)
+			assert.Equal(tt.want, <-codes)
+			assert.Equal(tt.wantErr, <-errs != nil)
+		})
+	}
+}
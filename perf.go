@@ -0,0 +1,274 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PerfType is key of rating category on lichess.org
+type PerfType string
+
+// Perf types of lichess.org
+const (
+	PerfUltraBullet    PerfType = "ultraBullet"
+	PerfBullet         PerfType = "bullet"
+	PerfBlitz          PerfType = "blitz"
+	PerfRapid          PerfType = "rapid"
+	PerfClassical      PerfType = "classical"
+	PerfCorrespondence PerfType = "correspondence"
+	PerfChess960       PerfType = "chess960"
+	PerfCrazyhouse     PerfType = "crazyhouse"
+	PerfAntichess      PerfType = "antichess"
+	PerfAtomic         PerfType = "atomic"
+	PerfHorde          PerfType = "horde"
+	PerfKingOfTheHill  PerfType = "kingOfTheHill"
+	PerfRacingKings    PerfType = "racingKings"
+	PerfThreeCheck     PerfType = "threeCheck"
+
+	// Puzzle perfs appear in User.Performances only
+	PerfPuzzle PerfType = "puzzle"
+	PerfStorm  PerfType = "storm"
+	PerfRacer  PerfType = "racer"
+	PerfStreak PerfType = "streak"
+)
+
+// VariantStandard is variant key of games rated in speed perfs
+const VariantStandard = "standard"
+
+// maxTopPlayers is max number of users returned by GetTop
+const maxTopPlayers = 200
+
+// speedPerfs are perfs of standard chess, named after game speed
+var speedPerfs = []PerfType{
+	PerfUltraBullet, PerfBullet, PerfBlitz, PerfRapid, PerfClassical, PerfCorrespondence,
+}
+
+// variantPerfs are perfs of chess variants, named after variant
+var variantPerfs = []PerfType{
+	PerfChess960, PerfCrazyhouse, PerfAntichess, PerfAtomic,
+	PerfHorde, PerfKingOfTheHill, PerfRacingKings, PerfThreeCheck,
+}
+
+// puzzlePerfs are perfs not related to games
+var puzzlePerfs = []PerfType{
+	PerfPuzzle, PerfStorm, PerfRacer, PerfStreak,
+}
+
+// GamePerfs returns perfs rated by playing games
+func GamePerfs() []PerfType {
+	perfs := make([]PerfType, 0, len(speedPerfs)+len(variantPerfs))
+	perfs = append(perfs, speedPerfs...)
+	return append(perfs, variantPerfs...)
+}
+
+// IsValid reports whether p is known perf type
+func (p PerfType) IsValid() bool {
+	return p.IsGame() || containsPerf(puzzlePerfs, p)
+}
+
+// IsGame reports whether p is rated by playing games
+func (p PerfType) IsGame() bool {
+	return containsPerf(speedPerfs, p) || containsPerf(variantPerfs, p)
+}
+
+// HasLeaderboard reports whether lichess.org keeps top players of p.
+// Every game perf except correspondence has leaderboard
+func (p PerfType) HasLeaderboard() bool {
+	return p.IsGame() && p != PerfCorrespondence
+}
+
+// Speed returns game speed of perf, empty for variants and puzzles
+// since their games may be played at any speed
+func (p PerfType) Speed() string {
+	if containsPerf(speedPerfs, p) {
+		return string(p)
+	}
+
+	return ""
+}
+
+// Variant returns variant key of perf, VariantStandard for speed perfs.
+// Empty for puzzles
+func (p PerfType) Variant() string {
+	switch {
+	case containsPerf(speedPerfs, p):
+		return VariantStandard
+	case containsPerf(variantPerfs, p):
+		return string(p)
+	}
+
+	return ""
+}
+
+// PerfTypeOf returns perf in which game of speed and variant is rated.
+// Variant games are rated in variant perf regardless of speed
+func PerfTypeOf(speed, variant string) (PerfType, error) {
+	if variant != "" && variant != VariantStandard {
+		if p := PerfType(variant); containsPerf(variantPerfs, p) {
+			return p, nil
+		}
+
+		return "", fmt.Errorf("Unknown variant %q", variant)
+	}
+
+	if p := PerfType(speed); containsPerf(speedPerfs, p) {
+		return p, nil
+	}
+
+	return "", fmt.Errorf("Unknown speed %q", speed)
+}
+
+func containsPerf(perfs []PerfType, p PerfType) bool {
+	for _, perf := range perfs {
+		if perf == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PerfStats stores detailed user statistics in one perf
+type PerfStats struct {
+	User       PerfStatsUser `json:"user"`
+	Perf       PerfSummary   `json:"perf"`
+	Rank       int           `json:"rank"` // Zero if user is not ranked
+	Percentile float64       `json:"percentile"`
+	Stat       PerfStat      `json:"stat"`
+}
+
+// PerfStatsUser identifies owner of perf statistics
+type PerfStatsUser struct {
+	Name string `json:"name"`
+}
+
+// PerfSummary stores current rating in perf
+type PerfSummary struct {
+	Glicko   Glicko `json:"glicko"`
+	Games    int    `json:"nb"`
+	Progress int    `json:"progress"`
+}
+
+// Glicko stores Glicko-2 rating
+type Glicko struct {
+	Rating      float64 `json:"rating"`
+	Deviation   float64 `json:"deviation"`
+	Provisional bool    `json:"provisional"`
+}
+
+// PerfStat stores user records in perf
+type PerfStat struct {
+	PerfType     PerfKey      `json:"perfType"`
+	Highest      *RatingAt    `json:"highest"`
+	Lowest       *RatingAt    `json:"lowest"`
+	BestWins     PerfResults  `json:"bestWins"`
+	WorstLosses  PerfResults  `json:"worstLosses"`
+	Count        PerfCount    `json:"count"`
+	ResultStreak ResultStreak `json:"resultStreak"`
+	PlayStreak   PlayStreak   `json:"playStreak"`
+}
+
+// PerfKey identifies perf
+type PerfKey struct {
+	Key  PerfType `json:"key"`
+	Name string   `json:"name"`
+}
+
+// RatingAt stores rating reached in game
+type RatingAt struct {
+	Rating int       `json:"int"`
+	At     time.Time `json:"at"`
+	GameID string    `json:"gameId"`
+}
+
+// PerfResults stores notable game results
+type PerfResults struct {
+	Results []PerfResult `json:"results"`
+}
+
+// PerfResult represents game result against opponent
+type PerfResult struct {
+	OpponentRating int       `json:"opRating"`
+	Opponent       LightUser `json:"opId"`
+	At             time.Time `json:"at"`
+	GameID         string    `json:"gameId"`
+}
+
+// PerfCount stores game results in perf
+type PerfCount struct {
+	All             int     `json:"all"`
+	Rated           int     `json:"rated"`
+	Win             int     `json:"win"`
+	Loss            int     `json:"loss"`
+	Draw            int     `json:"draw"`
+	Tournament      int     `json:"tour"`
+	Berserk         int     `json:"berserk"`
+	OpponentAverage float64 `json:"opAvg"`
+	Seconds         int     `json:"seconds"`
+	Disconnects     int     `json:"disconnects"`
+}
+
+// ResultStreak stores winning and losing streaks
+type ResultStreak struct {
+	Win  Streak `json:"win"`
+	Loss Streak `json:"loss"`
+}
+
+// PlayStreak stores streaks of consecutive games.
+// Time streak value is measured in seconds
+type PlayStreak struct {
+	Games    Streak    `json:"nb"`
+	Time     Streak    `json:"time"`
+	LastDate time.Time `json:"lastDate"`
+}
+
+// Streak stores current and longest streak
+type Streak struct {
+	Current StreakValue `json:"cur"`
+	Max     StreakValue `json:"max"`
+}
+
+// StreakValue stores streak length and games where it started and ended
+type StreakValue struct {
+	Value int          `json:"v"`
+	From  *StreakPoint `json:"from"`
+	To    *StreakPoint `json:"to"`
+}
+
+// StreakPoint represents game at streak boundary
+type StreakPoint struct {
+	At     time.Time `json:"at"`
+	GameID string    `json:"gameId"`
+}
+
+// GetUserPerfStats returns detailed statistics of user in game perf
+func (l *LichessAPI) GetUserPerfStats(ctx context.Context, username string, perf PerfType) (*PerfStats, error) {
+	var stats PerfStats
+
+	if !perf.IsGame() {
+		return nil, fmt.Errorf("Perf %q has no statistics", perf)
+	}
+
+	params := &reqParams{
+		name:        "userPerfStats",
+		requestType: http.MethodGet,
+		endpoint:    fmt.Sprintf(l.endpoint.userPerfStats, username, perf),
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
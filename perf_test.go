@@ -0,0 +1,223 @@
+package lichess
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetUserPerfStats(t *testing.T) {
+	assert := assert.New(t)
+
+	type args struct {
+		username string
+		perf     PerfType
+	}
+	type params struct {
+		requestType string
+		path        string
+		response    string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		params  params
+		want    *PerfStats
+		wantErr error
+	}{
+		{
+			name: "Get perf stats",
+			args: args{"georges", PerfBlitz},
+			params: params{
+				requestType: http.MethodGet,
+				path:        "/georges/blitz",
+				response: `{
+					"user": {"name": "Georges"},
+					"perf": {
+						"glicko": {"rating": 1864.33, "deviation": 45.12},
+						"nb": 1520,
+						"progress": 12
+					},
+					"rank": 3120,
+					"percentile": 84.6,
+					"stat": {
+						"perfType": {"key": "blitz", "name": "Blitz"},
+						"highest": {"int": 1950, "at": "2021-03-02T10:04:05Z", "gameId": "abcdefgh"},
+						"bestWins": {
+							"results": [{
+								"opRating": 2100,
+								"opId": {"id": "magnus", "name": "Magnus", "title": "GM"},
+								"at": "2021-03-01T10:00:00Z",
+								"gameId": "qwertyui"
+							}]
+						},
+						"count": {"all": 1520, "rated": 1500, "win": 800, "loss": 650, "draw": 70, "tour": 120, "opAvg": 1842.5, "seconds": 540000},
+						"resultStreak": {
+							"win": {
+								"cur": {"v": 2},
+								"max": {
+									"v": 9,
+									"from": {"at": "2020-05-01T10:00:00Z", "gameId": "aaaaaaaa"},
+									"to": {"at": "2020-05-02T10:00:00Z", "gameId": "bbbbbbbb"}
+								}
+							}
+						}
+					}
+				}`,
+			},
+			want: &PerfStats{
+				User: PerfStatsUser{Name: "Georges"},
+				Perf: PerfSummary{
+					Glicko:   Glicko{Rating: 1864.33, Deviation: 45.12},
+					Games:    1520,
+					Progress: 12,
+				},
+				Rank:       3120,
+				Percentile: 84.6,
+				Stat: PerfStat{
+					PerfType: PerfKey{Key: PerfBlitz, Name: "Blitz"},
+					Highest: &RatingAt{
+						Rating: 1950,
+						At:     time.Date(2021, time.March, 2, 10, 4, 5, 0, time.UTC),
+						GameID: "abcdefgh",
+					},
+					BestWins: PerfResults{
+						Results: []PerfResult{{
+							OpponentRating: 2100,
+							Opponent:       LightUser{ID: "magnus", Name: "Magnus", Title: "GM"},
+							At:             time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC),
+							GameID:         "qwertyui",
+						}},
+					},
+					Count: PerfCount{
+						All:             1520,
+						Rated:           1500,
+						Win:             800,
+						Loss:            650,
+						Draw:            70,
+						Tournament:      120,
+						OpponentAverage: 1842.5,
+						Seconds:         540000,
+					},
+					ResultStreak: ResultStreak{
+						Win: Streak{
+							Current: StreakValue{Value: 2},
+							Max: StreakValue{
+								Value: 9,
+								From:  &StreakPoint{At: time.Date(2020, time.May, 1, 10, 0, 0, 0, time.UTC), GameID: "aaaaaaaa"},
+								To:    &StreakPoint{At: time.Date(2020, time.May, 2, 10, 0, 0, 0, time.UTC), GameID: "bbbbbbbb"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(tt.params.requestType, req.Method)
+				assert.Equal(tt.params.path, req.URL.Path)
+
+				rw.Write([]byte(tt.params.response))
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:  "",
+				Client: server.Client(),
+			})
+			lapi.endpoint.userPerfStats = server.URL + "/%s/%s"
+
+			stats, err := lapi.GetUserPerfStats(context.Background(), tt.args.username, tt.args.perf)
+
+			assert.Equal(tt.want, stats)
+			assert.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func Test_PerfType(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name           string
+		perf           PerfType
+		valid          bool
+		game           bool
+		hasLeaderboard bool
+		speed          string
+		variant        string
+	}{
+		{
+			name:           "Speed perf",
+			perf:           PerfBlitz,
+			valid:          true,
+			game:           true,
+			hasLeaderboard: true,
+			speed:          "blitz",
+			variant:        VariantStandard,
+		},
+		{
+			name:           "Correspondence",
+			perf:           PerfCorrespondence,
+			valid:          true,
+			game:           true,
+			hasLeaderboard: false,
+			speed:          "correspondence",
+			variant:        VariantStandard,
+		},
+		{
+			name:           "Variant perf",
+			perf:           PerfKingOfTheHill,
+			valid:          true,
+			game:           true,
+			hasLeaderboard: true,
+			speed:          "",
+			variant:        "kingOfTheHill",
+		},
+		{
+			name:  "Puzzle perf",
+			perf:  PerfPuzzle,
+			valid: true,
+		},
+		{
+			name: "Unknown perf",
+			perf: PerfType("blits"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(tt.valid, tt.perf.IsValid())
+			assert.Equal(tt.game, tt.perf.IsGame())
+			assert.Equal(tt.hasLeaderboard, tt.perf.HasLeaderboard())
+			assert.Equal(tt.speed, tt.perf.Speed())
+			assert.Equal(tt.variant, tt.perf.Variant())
+		})
+	}
+}
+
+func Test_PerfTypeOf(t *testing.T) {
+	assert := assert.New(t)
+
+	perf, err := PerfTypeOf("rapid", VariantStandard)
+	assert.NoError(err)
+	assert.Equal(PerfRapid, perf)
+
+	perf, err = PerfTypeOf("blitz", "atomic")
+	assert.NoError(err)
+	assert.Equal(PerfAtomic, perf)
+
+	_, err = PerfTypeOf("blits", "")
+	assert.Error(err)
+
+	_, err = PerfTypeOf("blitz", "fromPosition")
+	assert.Error(err)
+}
@@ -0,0 +1,97 @@
+package lichess
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimitBackoff is time lichess.org asks to wait after 429 response
+const rateLimitBackoff = time.Minute
+
+// rateLimiter pauses requests made with token that received 429 response
+type rateLimiter struct {
+	mu      sync.Mutex
+	backoff time.Duration
+	until   map[string]time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		backoff: rateLimitBackoff,
+		until:   make(map[string]time.Time),
+	}
+}
+
+// wait blocks until token is no longer throttled or ctx is done
+func (r *rateLimiter) wait(ctx context.Context, token string) error {
+	for {
+		pause := time.Until(r.throttledUntil(token))
+		if pause <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// throttle pauses token for backoff period or retryAfter if it is longer.
+// Returns time when requests are allowed again
+func (r *rateLimiter) throttle(token string, retryAfter time.Duration) time.Time {
+	pause := r.backoff
+	if retryAfter > pause {
+		pause = retryAfter
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	until := time.Now().Add(pause)
+	if until.After(r.until[token]) {
+		r.until[token] = until
+	}
+
+	return r.until[token]
+}
+
+// throttledUntil returns time when token is allowed to make requests
+func (r *rateLimiter) throttledUntil(token string) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	until, ok := r.until[token]
+	if ok && !time.Now().Before(until) {
+		delete(r.until, token)
+		return time.Time{}
+	}
+
+	return until
+}
+
+// ThrottledUntil returns time until which requests with Config.Token
+// are paused after 429 response. Returns zero time if client is not throttled
+func (l *LichessAPI) ThrottledUntil() time.Time {
+	return l.limiter.throttledUntil(l.token)
+}
+
+// IsThrottled reports whether requests with Config.Token are paused after 429 response
+func (l *LichessAPI) IsThrottled() bool {
+	return !l.ThrottledUntil().IsZero()
+}
+
+// ThrottledUntilFor returns time until which requests made with ctx are paused,
+// taking token set by WithToken or WithAnonymous into account
+func (l *LichessAPI) ThrottledUntilFor(ctx context.Context) time.Time {
+	return l.limiter.throttledUntil(l.tokenFor(ctx))
+}
+
+// IsThrottledFor reports whether requests made with ctx are paused after 429 response
+func (l *LichessAPI) IsThrottledFor(ctx context.Context) bool {
+	return !l.ThrottledUntilFor(ctx).IsZero()
+}
@@ -0,0 +1,157 @@
+package lichess
+
+import (
+	"sort"
+	"time"
+)
+
+// Time returns day of rating as UTC midnight
+func (r DailyRating) Time() time.Time {
+	return time.Date(r.Year, time.Month(r.Month+1), r.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// RatingPoint stores rating at point of time
+type RatingPoint struct {
+	Time   time.Time
+	Rating int
+}
+
+// RatingSeries stores rating history of one perf ordered by time
+type RatingSeries []RatingPoint
+
+// NewRatingSeries creates series from ratings returned by GetUserRatingHistory
+func NewRatingSeries(ratings []DailyRating) RatingSeries {
+	series := make(RatingSeries, len(ratings))
+	for i, r := range ratings {
+		series[i] = RatingPoint{
+			Time:   r.Time(),
+			Rating: r.Rating,
+		}
+	}
+
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].Time.Before(series[j].Time)
+	})
+
+	return series
+}
+
+// At returns rating at t, carrying forward last rating known before t.
+// Reports false if t is before first point
+func (s RatingSeries) At(t time.Time) (int, bool) {
+	i := sort.Search(len(s), func(i int) bool {
+		return s[i].Time.After(t)
+	})
+	if i == 0 {
+		return 0, false
+	}
+
+	return s[i-1].Rating, true
+}
+
+// Between returns points in [from, to]
+func (s RatingSeries) Between(from, to time.Time) RatingSeries {
+	start := sort.Search(len(s), func(i int) bool {
+		return !s[i].Time.Before(from)
+	})
+	end := sort.Search(len(s), func(i int) bool {
+		return s[i].Time.After(to)
+	})
+	if start >= end {
+		return RatingSeries{}
+	}
+
+	return s[start:end]
+}
+
+// Peak returns first point with highest rating.
+// Reports false if series is empty
+func (s RatingSeries) Peak() (RatingPoint, bool) {
+	return s.extreme(func(a, b int) bool { return a > b })
+}
+
+// Trough returns first point with lowest rating.
+// Reports false if series is empty
+func (s RatingSeries) Trough() (RatingPoint, bool) {
+	return s.extreme(func(a, b int) bool { return a < b })
+}
+
+func (s RatingSeries) extreme(better func(a, b int) bool) (RatingPoint, bool) {
+	if len(s) == 0 {
+		return RatingPoint{}, false
+	}
+
+	best := s[0]
+	for _, p := range s[1:] {
+		if better(p.Rating, best.Rating) {
+			best = p
+		}
+	}
+
+	return best, true
+}
+
+// Change returns rating difference between from and to.
+// If series starts after from, its first rating is used instead.
+// Reports false if to is before first point
+func (s RatingSeries) Change(from, to time.Time) (int, bool) {
+	end, ok := s.At(to)
+	if !ok {
+		return 0, false
+	}
+
+	start, ok := s.At(from)
+	if !ok {
+		start = s[0].Rating
+	}
+
+	return end - start, true
+}
+
+// Weekly returns one point per week starting on Monday, from first to last point.
+// Each point holds rating at the end of its week
+func (s RatingSeries) Weekly() RatingSeries {
+	return s.resample(
+		func(t time.Time) time.Time {
+			offset := (int(t.Weekday()) + 6) % 7
+			return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+		},
+		func(t time.Time) time.Time {
+			return t.AddDate(0, 0, 7)
+		},
+	)
+}
+
+// Monthly returns one point per calendar month, from first to last point.
+// Each point holds rating at the end of its month
+func (s RatingSeries) Monthly() RatingSeries {
+	return s.resample(
+		func(t time.Time) time.Time {
+			return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		},
+		func(t time.Time) time.Time {
+			return t.AddDate(0, 1, 0)
+		},
+	)
+}
+
+// resample returns points at period starts with rating carried forward
+// to the end of period
+func (s RatingSeries) resample(start, next func(time.Time) time.Time) RatingSeries {
+	if len(s) == 0 {
+		return RatingSeries{}
+	}
+
+	var result RatingSeries
+
+	last := s[len(s)-1].Time.UTC()
+	for period := start(s[0].Time.UTC()); !period.After(last); period = next(period) {
+		rating, _ := s.At(next(period).Add(-time.Nanosecond))
+		result = append(result, RatingPoint{
+			Time:   period,
+			Rating: rating,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,112 @@
+package lichess
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func Test_RatingSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	// Lichess months are 0-based: 11 is December, 0 is January
+	series := NewRatingSeries([]DailyRating{
+		{2021, 0, 12, 1520},
+		{2020, 11, 30, 1500},
+		{2021, 0, 4, 1480},
+		{2021, 1, 2, 1610},
+	})
+
+	assert.Equal(RatingSeries{
+		{date(2020, time.December, 30), 1500},
+		{date(2021, time.January, 4), 1480},
+		{date(2021, time.January, 12), 1520},
+		{date(2021, time.February, 2), 1610},
+	}, series)
+
+	tests := []struct {
+		name   string
+		at     time.Time
+		want   int
+		wantOk bool
+	}{
+		{
+			name:   "Before first point",
+			at:     date(2020, time.December, 29),
+			wantOk: false,
+		},
+		{
+			name:   "Exact point",
+			at:     date(2021, time.January, 4),
+			want:   1480,
+			wantOk: true,
+		},
+		{
+			name:   "Carry forward",
+			at:     date(2021, time.January, 20),
+			want:   1520,
+			wantOk: true,
+		},
+		{
+			name:   "After last point",
+			at:     date(2022, time.January, 1),
+			want:   1610,
+			wantOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rating, ok := series.At(tt.at)
+
+			assert.Equal(tt.wantOk, ok)
+			assert.Equal(tt.want, rating)
+		})
+	}
+
+	peak, ok := series.Peak()
+	assert.True(ok)
+	assert.Equal(RatingPoint{date(2021, time.February, 2), 1610}, peak)
+
+	trough, ok := series.Trough()
+	assert.True(ok)
+	assert.Equal(RatingPoint{date(2021, time.January, 4), 1480}, trough)
+
+	trough, ok = series.Between(date(2021, time.January, 10), date(2021, time.March, 1)).Trough()
+	assert.True(ok)
+	assert.Equal(RatingPoint{date(2021, time.January, 12), 1520}, trough)
+
+	_, ok = RatingSeries{}.Peak()
+	assert.False(ok)
+
+	change, ok := series.Change(date(2021, time.January, 5), date(2021, time.February, 10))
+	assert.True(ok)
+	assert.Equal(130, change)
+
+	change, ok = series.Change(date(2020, time.January, 1), date(2021, time.January, 5))
+	assert.True(ok)
+	assert.Equal(-20, change)
+
+	_, ok = series.Change(date(2020, time.January, 1), date(2020, time.February, 1))
+	assert.False(ok)
+
+	assert.Equal(RatingSeries{
+		{date(2020, time.December, 28), 1500},
+		{date(2021, time.January, 4), 1480},
+		{date(2021, time.January, 11), 1520},
+		{date(2021, time.January, 18), 1520},
+		{date(2021, time.January, 25), 1520},
+		{date(2021, time.February, 1), 1610},
+	}, series.Weekly())
+
+	assert.Equal(RatingSeries{
+		{date(2020, time.December, 1), 1500},
+		{date(2021, time.January, 1), 1520},
+		{date(2021, time.February, 1), 1610},
+	}, series.Monthly())
+}
@@ -0,0 +1,64 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GetMyFollowing returns stream of users followed by logged in user.
+// Stream is stopped by its Close method or by cancelling ctx
+func (l *LichessAPI) GetMyFollowing(ctx context.Context) (*UserStream, error) {
+	params := &reqParams{
+		name:        "relFollowing",
+		requestType: http.MethodGet,
+		endpoint:    l.endpoint.relFollowing,
+		scopes:      []string{ScopeFollowRead},
+	}
+
+	stream, err := l.stream(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserStream{stream: stream}, nil
+}
+
+// FollowUser makes logged in user follow user
+func (l *LichessAPI) FollowUser(ctx context.Context, username string) error {
+	return l.changeRelation(ctx, "relFollow", l.endpoint.relFollow, username)
+}
+
+// UnfollowUser makes logged in user stop following user
+func (l *LichessAPI) UnfollowUser(ctx context.Context, username string) error {
+	return l.changeRelation(ctx, "relUnfollow", l.endpoint.relUnfollow, username)
+}
+
+// BlockUser makes logged in user block user
+func (l *LichessAPI) BlockUser(ctx context.Context, username string) error {
+	return l.changeRelation(ctx, "relBlock", l.endpoint.relBlock, username)
+}
+
+// UnblockUser makes logged in user stop blocking user
+func (l *LichessAPI) UnblockUser(ctx context.Context, username string) error {
+	return l.changeRelation(ctx, "relUnblock", l.endpoint.relUnblock, username)
+}
+
+// changeRelation posts relation change of logged in user towards username
+func (l *LichessAPI) changeRelation(ctx context.Context, name, endpoint, username string) error {
+	params := &reqParams{
+		name:        name,
+		requestType: http.MethodPost,
+		endpoint:    fmt.Sprintf(endpoint, username),
+		scopes:      []string{ScopeFollowWrite},
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	return nil
+}
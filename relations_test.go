@@ -0,0 +1,103 @@
+package lichess
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ChangeRelation(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name   string
+		call   func(lapi *LichessAPI) error
+		path   string
+		status int
+	}{
+		{
+			name:   "Follow user",
+			call:   func(lapi *LichessAPI) error { return lapi.FollowUser(context.Background(), "georges") },
+			path:   "/api/rel/follow/georges",
+			status: http.StatusOK,
+		},
+		{
+			name:   "Unfollow user",
+			call:   func(lapi *LichessAPI) error { return lapi.UnfollowUser(context.Background(), "georges") },
+			path:   "/api/rel/unfollow/georges",
+			status: http.StatusOK,
+		},
+		{
+			name:   "Block user",
+			call:   func(lapi *LichessAPI) error { return lapi.BlockUser(context.Background(), "georges") },
+			path:   "/api/rel/block/georges",
+			status: http.StatusOK,
+		},
+		{
+			name:   "Unblock unknown user",
+			call:   func(lapi *LichessAPI) error { return lapi.UnblockUser(context.Background(), "unknown") },
+			path:   "/api/rel/unblock/unknown",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(http.MethodPost, req.Method)
+				assert.Equal(tt.path, req.URL.Path)
+
+				rw.WriteHeader(tt.status)
+				rw.Write([]byte(`{"ok": true}`))
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:  "lip_bot",
+				Client: server.Client(),
+				Host:   server.URL,
+			})
+
+			err := tt.call(lapi)
+
+			if tt.status == http.StatusOK {
+				assert.NoError(err)
+			} else {
+				assert.True(IsNotFound(err))
+			}
+		})
+	}
+}
+
+func Test_GetMyFollowing(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(http.MethodGet, req.Method)
+		assert.Equal("/api/rel/following", req.URL.Path)
+
+		rw.Write([]byte("{\"id\": \"user1\"}\n{\"id\": \"user2\"}\n"))
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "lip_bot",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+
+	stream, err := lapi.GetMyFollowing(context.Background())
+	assert.NoError(err)
+	defer stream.Close()
+
+	var ids []string
+	for stream.Next() {
+		ids = append(ids, stream.Value().ID)
+	}
+
+	assert.NoError(stream.Err())
+	assert.Equal([]string{"user1", "user2"}, ids)
+}
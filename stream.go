@@ -0,0 +1,150 @@
+package lichess
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// ndjsonStream reads newline delimited json values from response body.
+// Body is closed when stream ends, is closed or its context is cancelled
+type ndjsonStream struct {
+	name   string
+	api    *LichessAPI
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+	reader *bufio.Reader
+	done   bool
+	err    error
+}
+
+// stream requests endpoint that responds with ndjson
+func (l *LichessAPI) stream(ctx context.Context, par *reqParams) (*ndjsonStream, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+
+	resp, err := l.request(streamCtx, par)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	s := &ndjsonStream{
+		name:   par.name,
+		api:    l,
+		parent: ctx,
+		ctx:    streamCtx,
+		cancel: cancel,
+		reader: bufio.NewReader(resp.Body),
+	}
+
+	// Closing body unblocks pending read when stream is cancelled
+	go func() {
+		<-streamCtx.Done()
+		resp.Body.Close()
+	}()
+
+	return s, nil
+}
+
+// next decodes next non-empty line into v.
+// Returns false when stream is finished, check err for the reason
+func (s *ndjsonStream) next(v interface{}) bool {
+	if s.done {
+		return false
+	}
+
+	for {
+		if s.ctx.Err() != nil {
+			s.finish(nil)
+			return false
+		}
+
+		line, err := s.reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			s.finish(err)
+			return false
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) != 0 {
+			s.api.onStreamLine(s.parent, s.name, line)
+
+			if decodeErr := json.Unmarshal(line, v); decodeErr != nil {
+				s.finish(decodeErr)
+				return false
+			}
+
+			return true
+		}
+
+		if err == io.EOF {
+			s.finish(nil)
+			return false
+		}
+	}
+}
+
+// finish stops stream and stores its terminal error
+func (s *ndjsonStream) finish(err error) {
+	s.done = true
+
+	if s.ctx.Err() != nil {
+		// Stream was cancelled: by parent context or by Close
+		err = s.parent.Err()
+	}
+
+	s.err = err
+	s.cancel()
+}
+
+// close stops stream and releases connection.
+// Safe to call concurrently with next
+func (s *ndjsonStream) close() error {
+	s.cancel()
+	return nil
+}
+
+// UserStream iterates over users streamed by lichess.org.
+// Always call Close when done reading
+type UserStream struct {
+	stream *ndjsonStream
+	user   User
+	// until ends stream before first user it returns true for
+	until func(u *User) bool
+}
+
+// Next reads next user from stream.
+// Returns false when stream is finished or failed
+func (s *UserStream) Next() bool {
+	s.user = User{}
+	if !s.stream.next(&s.user) {
+		return false
+	}
+
+	if s.until != nil && s.until(&s.user) {
+		s.stream.finish(nil)
+		s.user = User{}
+		return false
+	}
+
+	return true
+}
+
+// Value returns user read by last Next call
+func (s *UserStream) Value() User {
+	return s.user
+}
+
+// Err returns error that stopped stream, nil if stream ended normally or was closed
+func (s *UserStream) Err() error {
+	return s.stream.err
+}
+
+// Close stops stream and closes connection.
+// May be called from another goroutine to unblock Next
+func (s *UserStream) Close() error {
+	return s.stream.close()
+}
@@ -0,0 +1,148 @@
+package lichess
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UserStream(t *testing.T) {
+	assert := assert.New(t)
+
+	longBio := strings.Repeat("b", 200000)
+
+	tests := []struct {
+		name     string
+		response string
+		want     []User
+		wantErr  bool
+	}{
+		{
+			name:     "Keep-alive lines",
+			response: "\n{\"id\": \"user1\"}\n\n\n{\"id\": \"user2\"}\n\n",
+			want: []User{
+				{ID: "user1"},
+				{ID: "user2"},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Long line without trailing newline",
+			response: "{\"id\": \"user1\"}\n{\"id\": \"user2\", \"profile\": {\"bio\": \"" + longBio + "\"}}",
+			want: []User{
+				{ID: "user1"},
+				{ID: "user2", Profile: Profile{Bio: longBio}},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Malformed line",
+			response: "{\"id\": \"user1\"}\n{\"id\": \n{\"id\": \"user3\"}\n",
+			want: []User{
+				{ID: "user1"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:  "",
+				Client: server.Client(),
+			})
+			lapi.endpoint.teamMembers = server.URL + "/%s"
+
+			stream, err := lapi.GetTeamMembers(context.Background(), "team", nil)
+			assert.NoError(err)
+
+			defer stream.Close()
+
+			var users []User
+			for stream.Next() {
+				users = append(users, stream.Value())
+			}
+
+			assert.Equal(tt.want, users)
+			assert.Equal(tt.wantErr, stream.Err() != nil)
+			assert.False(stream.Next())
+		})
+	}
+}
+
+func Test_UserStreamCancel(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name    string
+		cancel  func(stream *UserStream, cancel context.CancelFunc)
+		wantErr error
+	}{
+		{
+			name: "Close stream",
+			cancel: func(stream *UserStream, cancel context.CancelFunc) {
+				stream.Close()
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Cancel context",
+			cancel: func(stream *UserStream, cancel context.CancelFunc) {
+				cancel()
+			},
+			wantErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			disconnected := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Write([]byte("{\"id\": \"user1\"}\n"))
+				rw.(http.Flusher).Flush()
+
+				<-req.Context().Done()
+				close(disconnected)
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:  "",
+				Client: server.Client(),
+			})
+			lapi.endpoint.teamMembers = server.URL + "/%s"
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stream, err := lapi.GetTeamMembers(ctx, "team", nil)
+			assert.NoError(err)
+
+			assert.True(stream.Next())
+			assert.Equal("user1", stream.Value().ID)
+
+			time.AfterFunc(20*time.Millisecond, func() {
+				tt.cancel(stream, cancel)
+			})
+
+			assert.False(stream.Next())
+			assert.Equal(tt.wantErr, stream.Err())
+
+			select {
+			case <-disconnected:
+			case <-time.After(time.Second):
+				t.Error("connection was not closed")
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultWatchInterval is time between polls of live streamers
+const defaultWatchInterval = time.Minute
+
+// Streamer stores live streaming user with stream and streamer profile
+type Streamer struct {
+	LightUser
+	Stream  Stream          `json:"stream"`
+	Profile StreamerProfile `json:"streamer"`
+}
+
+// Stream describes current live stream
+type Stream struct {
+	Service  string `json:"service"` // "twitch" or "youTube"
+	Status   string `json:"status"`  // Stream title
+	Language string `json:"lang"`
+}
+
+// StreamerProfile stores profile of streamer on lichess.org
+type StreamerProfile struct {
+	Name        string `json:"name"`
+	Headline    string `json:"headline"`
+	Description string `json:"description"`
+	Twitch      string `json:"twitch"`
+	YouTube     string `json:"youTube"`
+	Image       string `json:"image"`
+}
+
+// GetLiveStreamers returs current live streaming users
+func (l *LichessAPI) GetLiveStreamers(ctx context.Context) ([]Streamer, error) {
+	var streamers []Streamer
+
+	params := &reqParams{
+		name:        "userLiveStreaming",
+		requestType: http.MethodGet,
+		endpoint:    l.endpoint.userLiveStreaming,
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&streamers)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamers, nil
+}
+
+// StreamerEventType is kind of change of live streamers
+type StreamerEventType int
+
+// Types of StreamerEvent
+const (
+	StreamerWentLive StreamerEventType = iota + 1
+	StreamerWentOffline
+	// StreamerPollFailed reports failed poll, watcher retries after interval
+	StreamerPollFailed
+)
+
+// StreamerEvent reports that streamer went live or offline, or that poll failed.
+// Streamer of offline event holds last seen stream
+type StreamerEvent struct {
+	Type     StreamerEventType
+	Streamer Streamer
+	Err      error // Only in StreamerPollFailed event
+}
+
+// StreamerWatchOptions configures StreamerWatcher
+type StreamerWatchOptions struct {
+	// Interval between polls, one minute is used if zero
+	Interval time.Duration
+	// UserIDs restricts watching to given users, all streamers are watched if empty
+	UserIDs []string
+	// SkipInitial suppresses went live events of streamers live on first poll
+	SkipInitial bool
+}
+
+// StreamerWatcher polls live streamers and reports changes.
+// Failed polls are reported as StreamerPollFailed events and retried after interval.
+// Only Close may be called concurrently with Next.
+// Always call Close when done watching
+type StreamerWatcher struct {
+	api     *LichessAPI
+	parent  context.Context
+	ctx     context.Context
+	cancel  context.CancelFunc
+	opts    StreamerWatchOptions
+	users   map[string]bool
+	live    map[string]Streamer
+	polled  bool // Some poll succeeded
+	waiting bool // Next poll waits for interval
+	done    bool
+	pending []StreamerEvent
+	event   StreamerEvent
+	pollErr error
+	err     error
+}
+
+// WatchLiveStreamers returns watcher of live streamers.
+// Watcher is stopped by its Close method or by cancelling ctx. opts may be nil
+func (l *LichessAPI) WatchLiveStreamers(ctx context.Context, opts *StreamerWatchOptions) *StreamerWatcher {
+	watchCtx, cancel := context.WithCancel(ctx)
+
+	w := &StreamerWatcher{
+		api:    l,
+		parent: ctx,
+		ctx:    watchCtx,
+		cancel: cancel,
+		live:   make(map[string]Streamer),
+	}
+
+	if opts != nil {
+		w.opts = *opts
+	}
+	if w.opts.Interval <= 0 {
+		w.opts.Interval = defaultWatchInterval
+	}
+
+	if len(w.opts.UserIDs) != 0 {
+		w.users = make(map[string]bool)
+		for _, id := range w.opts.UserIDs {
+			w.users[strings.ToLower(id)] = true
+		}
+	}
+
+	return w
+}
+
+// Next waits for next event.
+// Returns false when watcher is closed or its context is cancelled
+func (w *StreamerWatcher) Next() bool {
+	if w.done {
+		return false
+	}
+
+	for len(w.pending) == 0 {
+		if w.waiting {
+			timer := time.NewTimer(w.opts.Interval)
+			select {
+			case <-w.ctx.Done():
+				timer.Stop()
+				w.finish()
+				return false
+			case <-timer.C:
+			}
+		}
+		w.waiting = true
+
+		// Failed poll keeps previous live streamers, changes are
+		// reported by next successful poll
+		err := w.poll()
+		if w.ctx.Err() != nil {
+			w.finish()
+			return false
+		}
+
+		w.pollErr = err
+		if err != nil {
+			w.pending = append(w.pending, StreamerEvent{Type: StreamerPollFailed, Err: err})
+		}
+	}
+
+	w.event = w.pending[0]
+	w.pending = w.pending[1:]
+
+	return true
+}
+
+// poll requests live streamers and queues changes since previous poll
+func (w *StreamerWatcher) poll() error {
+	streamers, err := w.api.GetLiveStreamers(w.ctx)
+	if err != nil {
+		return err
+	}
+
+	initial := !w.polled
+	w.polled = true
+
+	live := make(map[string]Streamer)
+	for _, s := range streamers {
+		if w.users != nil && !w.users[s.ID] {
+			continue
+		}
+
+		live[s.ID] = s
+		if _, ok := w.live[s.ID]; !ok && !(initial && w.opts.SkipInitial) {
+			w.pending = append(w.pending, StreamerEvent{Type: StreamerWentLive, Streamer: s})
+		}
+	}
+
+	var offline []Streamer
+	for id, s := range w.live {
+		if _, ok := live[id]; !ok {
+			offline = append(offline, s)
+		}
+	}
+
+	sortStreamers(offline)
+	for _, s := range offline {
+		w.pending = append(w.pending, StreamerEvent{Type: StreamerWentOffline, Streamer: s})
+	}
+
+	w.live = live
+
+	return nil
+}
+
+// finish stops cancelled watcher, by parent context or by Close
+func (w *StreamerWatcher) finish() {
+	w.done = true
+	w.err = w.parent.Err()
+	w.cancel()
+}
+
+// Value returns event read by last Next call
+func (w *StreamerWatcher) Value() StreamerEvent {
+	return w.event
+}
+
+// Live returns streamers live on last successful poll ordered by id.
+// Must not be called concurrently with Next
+func (w *StreamerWatcher) Live() []Streamer {
+	live := make([]Streamer, 0, len(w.live))
+	for _, s := range w.live {
+		live = append(live, s)
+	}
+
+	sortStreamers(live)
+
+	return live
+}
+
+// sortStreamers orders streamers by id
+func sortStreamers(streamers []Streamer) {
+	sort.Slice(streamers, func(i, j int) bool {
+		return streamers[i].ID < streamers[j].ID
+	})
+}
+
+// LastPollErr returns error of last poll, nil if it succeeded.
+// Must not be called concurrently with Next
+func (w *StreamerWatcher) LastPollErr() error {
+	return w.pollErr
+}
+
+// Err returns context error if watcher was stopped by cancelling its context,
+// nil if watcher was closed
+func (w *StreamerWatcher) Err() error {
+	return w.err
+}
+
+// Close stops watcher.
+// May be called from another goroutine to unblock Next
+func (w *StreamerWatcher) Close() error {
+	w.cancel()
+	return nil
+}
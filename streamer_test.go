@@ -0,0 +1,234 @@
+package lichess
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetLiveStreamers(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(http.MethodGet, req.Method)
+		assert.Equal("/api/streamer/live", req.URL.Path)
+
+		rw.Write([]byte(`[{
+			"id": "chessnetwork",
+			"name": "ChessNetwork",
+			"patron": true,
+			"stream": {"service": "twitch", "status": "Blitz with viewers", "lang": "en"},
+			"streamer": {
+				"name": "ChessNetwork",
+				"headline": "Chess Commentary",
+				"twitch": "https://twitch.tv/chessnetwork",
+				"image": "https://image.lichess1.org/display?h=350"
+			}
+		}]`))
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+
+	streamers, err := lapi.GetLiveStreamers(context.Background())
+
+	assert.NoError(err)
+	assert.Equal([]Streamer{
+		{
+			LightUser: LightUser{
+				ID:     "chessnetwork",
+				Name:   "ChessNetwork",
+				Patron: true,
+			},
+			Stream: Stream{
+				Service:  "twitch",
+				Status:   "Blitz with viewers",
+				Language: "en",
+			},
+			Profile: StreamerProfile{
+				Name:     "ChessNetwork",
+				Headline: "Chess Commentary",
+				Twitch:   "https://twitch.tv/chessnetwork",
+				Image:    "https://image.lichess1.org/display?h=350",
+			},
+		},
+	}, streamers)
+}
+
+func Test_WatchLiveStreamers(t *testing.T) {
+	assert := assert.New(t)
+
+	polls := []string{
+		`[{"id": "user1"}, {"id": "user2"}, {"id": "other"}]`,
+		`[{"id": "user2"}, {"id": "user3"}]`,
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if requests < len(polls) {
+			rw.Write([]byte(polls[requests]))
+		} else {
+			rw.Write([]byte(polls[len(polls)-1]))
+		}
+		requests++
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+
+	watcher := lapi.WatchLiveStreamers(context.Background(), &StreamerWatchOptions{
+		Interval: 10 * time.Millisecond,
+		UserIDs:  []string{"User1", "user2", "user3"},
+	})
+
+	type event struct {
+		eventType StreamerEventType
+		id        string
+	}
+
+	var events []event
+	for len(events) < 4 && watcher.Next() {
+		events = append(events, event{watcher.Value().Type, watcher.Value().Streamer.ID})
+	}
+
+	assert.ElementsMatch([]event{
+		{StreamerWentLive, "user1"},
+		{StreamerWentLive, "user2"},
+	}, events[:2])
+	assert.Equal([]event{
+		{StreamerWentLive, "user3"},
+		{StreamerWentOffline, "user1"},
+	}, events[2:])
+
+	live := watcher.Live()
+	assert.Len(live, 2)
+	assert.Equal("user2", live[0].ID)
+	assert.Equal("user3", live[1].ID)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		watcher.Close()
+	}()
+
+	assert.False(watcher.Next())
+	assert.NoError(watcher.Err())
+}
+
+func Test_WatchLiveStreamersError(t *testing.T) {
+	assert := assert.New(t)
+
+	polls := []string{
+		"",
+		`[{"id": "user3"}, {"id": "user1"}, {"id": "user2"}]`,
+		`[]`,
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case requests == 0:
+			rw.WriteHeader(http.StatusInternalServerError)
+		case requests < len(polls):
+			rw.Write([]byte(polls[requests]))
+		default:
+			rw.Write([]byte(polls[len(polls)-1]))
+		}
+		requests++
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := lapi.WatchLiveStreamers(ctx, &StreamerWatchOptions{Interval: 10 * time.Millisecond})
+	defer watcher.Close()
+
+	type event struct {
+		eventType StreamerEventType
+		id        string
+	}
+
+	var events []event
+	for len(events) < 7 && watcher.Next() {
+		events = append(events, event{watcher.Value().Type, watcher.Value().Streamer.ID})
+	}
+
+	assert.Equal([]event{
+		{StreamerPollFailed, ""},
+		{StreamerWentLive, "user3"},
+		{StreamerWentLive, "user1"},
+		{StreamerWentLive, "user2"},
+		{StreamerWentOffline, "user1"},
+		{StreamerWentOffline, "user2"},
+		{StreamerWentOffline, "user3"},
+	}, events)
+	assert.NoError(watcher.LastPollErr())
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	assert.False(watcher.Next())
+	assert.ErrorIs(watcher.Err(), context.Canceled)
+}
+
+func Test_WatchLiveStreamersPollErr(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+
+	watcher := lapi.WatchLiveStreamers(context.Background(), &StreamerWatchOptions{Interval: 10 * time.Millisecond})
+	defer watcher.Close()
+
+	// Every failed poll is reported without stopping watcher
+	for i := 0; i < 3; i++ {
+		assert.True(watcher.Next())
+
+		event := watcher.Value()
+		assert.Equal(StreamerPollFailed, event.Type)
+
+		var apiErr *APIError
+		assert.ErrorAs(event.Err, &apiErr)
+		assert.Equal(http.StatusInternalServerError, apiErr.StatusCode)
+		assert.Equal(event.Err, watcher.LastPollErr())
+		assert.Empty(watcher.Live())
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		watcher.Close()
+	}()
+
+	for watcher.Next() {
+		assert.Equal(StreamerPollFailed, watcher.Value().Type)
+	}
+	assert.NoError(watcher.Err())
+}
@@ -0,0 +1,361 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Team stores information about lichess.org team.
+// URL is filled by client since API responses only contain id
+type Team struct {
+	TeamActivity
+	ID            string      `json:"id"`
+	Description   string      `json:"description"`
+	Flair         string      `json:"flair"`
+	Open          bool        `json:"open"` // Users may join without request
+	Leader        LightUser   `json:"leader"`
+	Leaders       []LightUser `json:"leaders"`
+	NumberMembers int         `json:"nbMembers"`
+	Joined        bool        `json:"joined"`    // Only with token
+	Requested     bool        `json:"requested"` // Only with token
+}
+
+// TeamPage stores one page of paginated teams
+type TeamPage struct {
+	CurrentPage   int    `json:"currentPage"`
+	MaxPerPage    int    `json:"maxPerPage"`
+	Teams         []Team `json:"currentPageResults"`
+	NumberResults int    `json:"nbResults"`
+	PreviousPage  int    `json:"previousPage"` // Zero on first page
+	NextPage      int    `json:"nextPage"`     // Zero on last page
+	NumberPages   int    `json:"nbPages"`
+}
+
+// TeamMembersOptions stores optional parameters of GetTeamMembers
+type TeamMembersOptions struct {
+	// Full streams full user profiles instead of light users, lichess.org
+	// sends them at slower rate
+	Full bool
+	// JoinedSince stops stream at first member who joined before it.
+	// Lichess.org has no such filter, members are streamed by join date.
+	// Members without join date are streamed and never stop stream
+	JoinedSince time.Time
+}
+
+// TeamMembersDiff stores changes of team members between two snapshots
+type TeamMembersDiff struct {
+	Joined []User
+	Left   []User // Users of previous snapshot
+}
+
+// TeamJoinOptions stores optional parameters of team join request
+type TeamJoinOptions struct {
+	// Message is sent to leaders of closed team
+	Message string
+	// Password is required by teams with entry code
+	Password string
+}
+
+// TeamJoinRequest stores pending or declined request to join team
+type TeamJoinRequest struct {
+	Request TeamRequest `json:"request"`
+	User    User        `json:"user"`
+}
+
+// TeamRequest describes request to join team
+type TeamRequest struct {
+	TeamID  string    `json:"teamId"`
+	UserID  string    `json:"userId"`
+	Date    Timestamp `json:"date"`
+	Message string    `json:"message"`
+}
+
+// GetTeam returns information about team
+func (l *LichessAPI) GetTeam(ctx context.Context, id string) (*Team, error) {
+	var team Team
+
+	params := &reqParams{
+		name:        "team",
+		requestType: http.MethodGet,
+		endpoint:    fmt.Sprintf(l.endpoint.team, id),
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&team)
+	if err != nil {
+		return nil, err
+	}
+
+	team.URL = fmt.Sprintf(l.endpoint.teamPage, team.ID)
+
+	return &team, nil
+}
+
+// GetPopularTeams returns page of teams sorted by popularity, pages start from 1
+func (l *LichessAPI) GetPopularTeams(ctx context.Context, page int) (*TeamPage, error) {
+	params := &reqParams{
+		name:        "teamAll",
+		requestType: http.MethodGet,
+		endpoint:    l.endpoint.teamAll,
+		query: map[string]string{
+			"page": strconv.Itoa(page),
+		},
+	}
+
+	return l.getTeamPage(ctx, params)
+}
+
+// SearchTeams returns page of teams matching text, pages start from 1
+func (l *LichessAPI) SearchTeams(ctx context.Context, text string, page int) (*TeamPage, error) {
+	params := &reqParams{
+		name:        "teamSearch",
+		requestType: http.MethodGet,
+		endpoint:    l.endpoint.teamSearch,
+		query: map[string]string{
+			"text": text,
+			"page": strconv.Itoa(page),
+		},
+	}
+
+	return l.getTeamPage(ctx, params)
+}
+
+// getTeamPage requests paginated teams
+func (l *LichessAPI) getTeamPage(ctx context.Context, params *reqParams) (*TeamPage, error) {
+	var page TeamPage
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&page)
+	if err != nil {
+		return nil, err
+	}
+
+	l.fillTeamURLs(page.Teams)
+
+	return &page, nil
+}
+
+// GetTeamsOfUser returns teams user is member of
+func (l *LichessAPI) GetTeamsOfUser(ctx context.Context, username string) ([]Team, error) {
+	var teams []Team
+
+	params := &reqParams{
+		name:        "teamsOfUser",
+		requestType: http.MethodGet,
+		endpoint:    fmt.Sprintf(l.endpoint.teamsOfUser, username),
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&teams)
+	if err != nil {
+		return nil, err
+	}
+
+	l.fillTeamURLs(teams)
+
+	return teams, nil
+}
+
+// fillTeamURLs sets URL of teams from their ids
+func (l *LichessAPI) fillTeamURLs(teams []Team) {
+	for i := range teams {
+		teams[i].URL = fmt.Sprintf(l.endpoint.teamPage, teams[i].ID)
+	}
+}
+
+// GetTeamMembers returns stream of team members, most recently joined first.
+// Stream is stopped by its Close method or by cancelling ctx. opts may be nil
+func (l *LichessAPI) GetTeamMembers(ctx context.Context, id string, opts *TeamMembersOptions) (*UserStream, error) {
+	query := map[string]string{}
+	if opts != nil && opts.Full {
+		query["full"] = "true"
+	}
+
+	params := &reqParams{
+		name:        "teamMembers",
+		requestType: http.MethodGet,
+		endpoint:    fmt.Sprintf(l.endpoint.teamMembers, id),
+		query:       query,
+	}
+
+	stream, err := l.stream(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	users := &UserStream{stream: stream}
+
+	if opts != nil && !opts.JoinedSince.IsZero() {
+		since := opts.JoinedSince
+		users.until = func(u *User) bool {
+			// Members without join date are kept rather than ending stream early
+			return !u.JoinedTeamAt.IsZero() && u.JoinedTeamAt.Before(since)
+		}
+	}
+
+	return users, nil
+}
+
+// DiffTeamMembers compares two snapshots of team members by user id
+func DiffTeamMembers(prev, next []User) TeamMembersDiff {
+	var diff TeamMembersDiff
+
+	prevIDs := make(map[string]bool, len(prev))
+	for _, u := range prev {
+		prevIDs[u.ID] = true
+	}
+
+	nextIDs := make(map[string]bool, len(next))
+	for _, u := range next {
+		nextIDs[u.ID] = true
+
+		if !prevIDs[u.ID] {
+			diff.Joined = append(diff.Joined, u)
+		}
+	}
+
+	for _, u := range prev {
+		if !nextIDs[u.ID] {
+			diff.Left = append(diff.Left, u)
+		}
+	}
+
+	return diff
+}
+
+// JoinTeam joins team or sends join request to closed team.
+// opts may be nil
+func (l *LichessAPI) JoinTeam(ctx context.Context, id string, opts *TeamJoinOptions) error {
+	form := url.Values{}
+	if opts != nil {
+		if opts.Message != "" {
+			form.Set("message", opts.Message)
+		}
+		if opts.Password != "" {
+			form.Set("password", opts.Password)
+		}
+	}
+
+	return l.postTeam(ctx, &reqParams{
+		name:     "teamJoin",
+		endpoint: fmt.Sprintf(l.endpoint.teamJoin, id),
+		form:     form,
+		scopes:   []string{ScopeTeamWrite},
+	})
+}
+
+// LeaveTeam leaves team
+func (l *LichessAPI) LeaveTeam(ctx context.Context, id string) error {
+	return l.postTeam(ctx, &reqParams{
+		name:     "teamQuit",
+		endpoint: fmt.Sprintf(l.endpoint.teamQuit, id),
+		scopes:   []string{ScopeTeamWrite},
+	})
+}
+
+// KickTeamMember removes user from team led by logged in user
+func (l *LichessAPI) KickTeamMember(ctx context.Context, id, userID string) error {
+	return l.postTeam(ctx, &reqParams{
+		name:     "teamKick",
+		endpoint: fmt.Sprintf(l.endpoint.teamKick, id, userID),
+		scopes:   []string{ScopeTeamLead},
+	})
+}
+
+// GetTeamJoinRequests returns pending join requests of team led by logged in user.
+// Declined requests are returned instead if declined is true
+func (l *LichessAPI) GetTeamJoinRequests(ctx context.Context, id string, declined bool) ([]TeamJoinRequest, error) {
+	var requests []TeamJoinRequest
+
+	query := map[string]string{}
+	if declined {
+		query["declined"] = "true"
+	}
+
+	params := &reqParams{
+		name:        "teamRequests",
+		requestType: http.MethodGet,
+		endpoint:    fmt.Sprintf(l.endpoint.teamRequests, id),
+		query:       query,
+		scopes:      []string{ScopeTeamRead},
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&requests)
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// AcceptTeamJoinRequest accepts user's request to join team
+func (l *LichessAPI) AcceptTeamJoinRequest(ctx context.Context, id, userID string) error {
+	return l.postTeam(ctx, &reqParams{
+		name:     "teamRequestAccept",
+		endpoint: fmt.Sprintf(l.endpoint.teamRequestAccept, id, userID),
+		scopes:   []string{ScopeTeamLead},
+	})
+}
+
+// DeclineTeamJoinRequest declines user's request to join team
+func (l *LichessAPI) DeclineTeamJoinRequest(ctx context.Context, id, userID string) error {
+	return l.postTeam(ctx, &reqParams{
+		name:     "teamRequestDecline",
+		endpoint: fmt.Sprintf(l.endpoint.teamRequestDecline, id, userID),
+		scopes:   []string{ScopeTeamLead},
+	})
+}
+
+// MessageTeam sends private message to all members of team
+func (l *LichessAPI) MessageTeam(ctx context.Context, id, message string) error {
+	return l.postTeam(ctx, &reqParams{
+		name:     "teamMessageAll",
+		endpoint: fmt.Sprintf(l.endpoint.teamMessageAll, id),
+		form:     url.Values{"message": {message}},
+		scopes:   []string{ScopeTeamLead},
+	})
+}
+
+// postTeam posts team action and discards response
+func (l *LichessAPI) postTeam(ctx context.Context, params *reqParams) error {
+	params.requestType = http.MethodPost
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,342 @@
+package lichess
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetTeam(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(http.MethodGet, req.Method)
+
+		switch req.URL.Path {
+		case "/api/team/coders":
+			rw.Write([]byte(`{
+				"id": "coders",
+				"name": "Coders",
+				"description": "Programmers who play chess",
+				"open": true,
+				"leader": {"id": "thibault", "name": "thibault"},
+				"leaders": [{"id": "thibault", "name": "thibault"}],
+				"nbMembers": 4321
+			}`))
+		case "/api/team/search":
+			assert.Equal("code", req.URL.Query().Get("text"))
+			assert.Equal("2", req.URL.Query().Get("page"))
+
+			rw.Write([]byte(`{
+				"currentPage": 2,
+				"maxPerPage": 15,
+				"currentPageResults": [{"id": "coders", "name": "Coders"}],
+				"nbResults": 16,
+				"previousPage": 1,
+				"nextPage": null,
+				"nbPages": 2
+			}`))
+		case "/api/team/of/georges":
+			rw.Write([]byte(`[{"id": "coders", "name": "Coders", "joined": true}]`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+
+	team, err := lapi.GetTeam(context.Background(), "coders")
+
+	assert.NoError(err)
+	assert.Equal(&Team{
+		TeamActivity: TeamActivity{
+			URL:  server.URL + "/team/coders",
+			Name: "Coders",
+		},
+		ID:            "coders",
+		Description:   "Programmers who play chess",
+		Open:          true,
+		Leader:        LightUser{ID: "thibault", Name: "thibault"},
+		Leaders:       []LightUser{{ID: "thibault", Name: "thibault"}},
+		NumberMembers: 4321,
+	}, team)
+
+	page, err := lapi.SearchTeams(context.Background(), "code", 2)
+
+	assert.NoError(err)
+	assert.Equal(&TeamPage{
+		CurrentPage: 2,
+		MaxPerPage:  15,
+		Teams: []Team{
+			{
+				TeamActivity: TeamActivity{URL: server.URL + "/team/coders", Name: "Coders"},
+				ID:           "coders",
+			},
+		},
+		NumberResults: 16,
+		PreviousPage:  1,
+		NumberPages:   2,
+	}, page)
+
+	teams, err := lapi.GetTeamsOfUser(context.Background(), "georges")
+
+	assert.NoError(err)
+	assert.Len(teams, 1)
+	assert.True(teams[0].Joined)
+	assert.Equal(server.URL+"/team/coders", teams[0].URL)
+
+	_, err = lapi.GetTeam(context.Background(), "unknown")
+	assert.True(IsNotFound(err))
+}
+
+func Test_TeamActions(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name string
+		call func(lapi *LichessAPI) error
+		path string
+		body string
+	}{
+		{
+			name: "Join team",
+			call: func(lapi *LichessAPI) error {
+				return lapi.JoinTeam(context.Background(), "coders", &TeamJoinOptions{Message: "Hello", Password: "secret"})
+			},
+			path: "/team/coders/join",
+			body: "message=Hello&password=secret",
+		},
+		{
+			name: "Leave team",
+			call: func(lapi *LichessAPI) error { return lapi.LeaveTeam(context.Background(), "coders") },
+			path: "/team/coders/quit",
+		},
+		{
+			name: "Kick member",
+			call: func(lapi *LichessAPI) error { return lapi.KickTeamMember(context.Background(), "coders", "georges") },
+			path: "/api/team/coders/kick/georges",
+		},
+		{
+			name: "Accept join request",
+			call: func(lapi *LichessAPI) error {
+				return lapi.AcceptTeamJoinRequest(context.Background(), "coders", "georges")
+			},
+			path: "/api/team/coders/request/georges/accept",
+		},
+		{
+			name: "Decline join request",
+			call: func(lapi *LichessAPI) error {
+				return lapi.DeclineTeamJoinRequest(context.Background(), "coders", "georges")
+			},
+			path: "/api/team/coders/request/georges/decline",
+		},
+		{
+			name: "Message all members",
+			call: func(lapi *LichessAPI) error {
+				return lapi.MessageTeam(context.Background(), "coders", "Arena at 18:00")
+			},
+			path: "/team/coders/pm-all",
+			body: "message=Arena+at+18%3A00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(http.MethodPost, req.Method)
+				assert.Equal(tt.path, req.URL.Path)
+
+				body, _ := ioutil.ReadAll(req.Body)
+				assert.Equal(tt.body, string(body))
+
+				rw.Write([]byte(`{"ok": true}`))
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:  "lip_leader",
+				Client: server.Client(),
+				Host:   server.URL,
+			})
+
+			assert.NoError(tt.call(lapi))
+		})
+	}
+}
+
+func Test_GetTeamJoinRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal("/api/team/coders/requests", req.URL.Path)
+		assert.Equal("true", req.URL.Query().Get("declined"))
+
+		rw.Write([]byte(`[{
+			"request": {"teamId": "coders", "userId": "georges", "date": 1522636452014, "message": "Hi"},
+			"user": {"id": "georges", "username": "Georges"}
+		}]`))
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:  "lip_leader",
+		Client: server.Client(),
+		Host:   server.URL,
+	})
+
+	requests, err := lapi.GetTeamJoinRequests(context.Background(), "coders", true)
+
+	assert.NoError(err)
+	assert.Equal([]TeamJoinRequest{
+		{
+			Request: TeamRequest{
+				TeamID:  "coders",
+				UserID:  "georges",
+				Date:    Timestamp{unixMilli(1522636452014)},
+				Message: "Hi",
+			},
+			User: User{ID: "georges", Username: "Georges"},
+		},
+	}, requests)
+}
+
+func Test_GetTeamMembers(t *testing.T) {
+	assert := assert.New(t)
+
+	type args struct {
+		ID      string
+		Options *TeamMembersOptions
+	}
+	type params struct {
+		requestType string
+		requestBody string
+		response    string
+		query       map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		params  params
+		want    []User
+		wantErr error
+	}{
+		{
+			name: "Get team members",
+			args: args{
+				ID: "team",
+			},
+			params: params{
+				requestType: http.MethodGet,
+				response: "{\"id\": \"bahadirozen\", \"username\": \"BahadirOzen\"}\n" +
+					"{\"id\": \"penguingim1\", \"username\": \"penguingim1\"}\n",
+			},
+			want: []User{
+				{
+					ID:       "bahadirozen",
+					Username: "BahadirOzen",
+				},
+				{
+					ID:       "penguingim1",
+					Username: "penguingim1",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Get full profiles of members joined since date",
+			args: args{
+				ID: "team",
+				Options: &TeamMembersOptions{
+					Full:        true,
+					JoinedSince: unixMilli(1600000000000),
+				},
+			},
+			params: params{
+				requestType: http.MethodGet,
+				query: map[string]string{
+					"full": "true",
+				},
+				response: "{\"id\": \"user4\"}\n" +
+					"{\"id\": \"user3\", \"joinedTeamAt\": 1600000002000}\n" +
+					"{\"id\": \"user2\", \"joinedTeamAt\": 1600000000000}\n" +
+					"{\"id\": \"user1\", \"joinedTeamAt\": 1500000000000}\n",
+			},
+			want: []User{
+				{
+					ID: "user4",
+				},
+				{
+					ID:           "user3",
+					JoinedTeamAt: Timestamp{unixMilli(1600000002000)},
+				},
+				{
+					ID:           "user2",
+					JoinedTeamAt: Timestamp{unixMilli(1600000000000)},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(tt.params.requestType, req.Method)
+
+				body, _ := ioutil.ReadAll(req.Body)
+				assert.Equal(tt.params.requestBody, string(body))
+
+				query := req.URL.Query()
+				assert.Len(query, len(tt.params.query))
+				for k, v := range tt.params.query {
+					assert.Equal(v, query.Get(k))
+				}
+
+				rw.Write([]byte(tt.params.response))
+			}))
+
+			lapi := NewLichessAPI(Config{
+				Token:  "",
+				Client: server.Client(),
+			})
+			lapi.endpoint.teamMembers = server.URL + "/%s"
+
+			stream, err := lapi.GetTeamMembers(context.Background(), tt.args.ID, tt.args.Options)
+			assert.Equal(tt.wantErr, err)
+
+			defer stream.Close()
+
+			var users []User
+
+			for stream.Next() {
+				users = append(users, stream.Value())
+			}
+
+			assert.Equal(tt.want, users)
+			assert.NoError(stream.Err())
+		})
+	}
+}
+
+func Test_DiffTeamMembers(t *testing.T) {
+	assert := assert.New(t)
+
+	prev := []User{{ID: "user1"}, {ID: "user2"}, {ID: "user3"}}
+	next := []User{{ID: "user4"}, {ID: "user2"}, {ID: "user3"}, {ID: "user5"}}
+
+	assert.Equal(TeamMembersDiff{
+		Joined: []User{{ID: "user4"}, {ID: "user5"}},
+		Left:   []User{{ID: "user1"}},
+	}, DiffTeamMembers(prev, next))
+
+	assert.Equal(TeamMembersDiff{}, DiffTeamMembers(prev, prev))
+}
@@ -0,0 +1,93 @@
+package lichess
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Timestamp is time encoded as milliseconds since epoch by lichess.org.
+// Zero or null value decodes to zero time and zero time encodes to 0
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp creates Timestamp from time truncated to milliseconds
+func NewTimestamp(t time.Time) Timestamp {
+	if t.IsZero() {
+		return Timestamp{}
+	}
+
+	return Timestamp{unixMilli(t.UnixNano() / int64(time.Millisecond))}
+}
+
+// MarshalJSON for Timestamp struct
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("0"), nil
+	}
+
+	ms := t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
+
+	return []byte(strconv.FormatInt(ms, 10)), nil
+}
+
+// UnmarshalJSON for Timestamp struct
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var ms int64
+	if err := json.Unmarshal(data, &ms); err != nil {
+		return err
+	}
+
+	if ms == 0 {
+		t.Time = time.Time{}
+	} else {
+		t.Time = unixMilli(ms)
+	}
+
+	return nil
+}
+
+// unixMilli returns local time of milliseconds since epoch
+func unixMilli(ms int64) time.Time {
+	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond))
+}
+
+// Duration is time interval encoded as seconds by lichess.org
+type Duration struct {
+	time.Duration
+}
+
+// MarshalJSON for Duration struct.
+// Whole seconds are encoded as integer to keep round trip lossless
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if d.Duration%time.Second == 0 {
+		return []byte(strconv.FormatInt(int64(d.Duration/time.Second), 10)), nil
+	}
+
+	return []byte(strconv.FormatFloat(d.Seconds(), 'f', -1, 64)), nil
+}
+
+// UnmarshalJSON for Duration struct
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		d.Duration = 0
+		return nil
+	}
+
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return err
+	}
+
+	d.Duration = time.Duration(math.Round(seconds * float64(time.Second)))
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package lichess
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Timestamp(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		data     string
+		want     time.Time
+		wantData string
+	}{
+		{
+			name:     "Milliseconds",
+			data:     "1522636452014",
+			want:     time.Date(2018, time.April, 2, 2, 34, 12, 14000000, time.UTC),
+			wantData: "1522636452014",
+		},
+		{
+			name:     "Zero",
+			data:     "0",
+			want:     time.Time{},
+			wantData: "0",
+		},
+		{
+			name:     "Null",
+			data:     "null",
+			want:     time.Time{},
+			wantData: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts Timestamp
+			err := json.Unmarshal([]byte(tt.data), &ts)
+
+			assert.NoError(err)
+			assert.True(tt.want.Equal(ts.Time))
+
+			data, err := json.Marshal(ts)
+
+			assert.NoError(err)
+			assert.Equal(tt.wantData, string(data))
+		})
+	}
+
+	ts := NewTimestamp(time.Date(2021, time.May, 1, 10, 0, 0, 123456789, time.UTC))
+	assert.Equal(123000000, ts.Nanosecond())
+}
+
+func Test_Duration(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		data     string
+		want     time.Duration
+		wantData string
+	}{
+		{
+			name:     "Whole seconds",
+			data:     "180",
+			want:     3 * time.Minute,
+			wantData: "180",
+		},
+		{
+			name:     "Fractional seconds",
+			data:     "0.5",
+			want:     500 * time.Millisecond,
+			wantData: "0.5",
+		},
+		{
+			name:     "Null",
+			data:     "null",
+			want:     0,
+			wantData: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Duration
+			err := json.Unmarshal([]byte(tt.data), &d)
+
+			assert.NoError(err)
+			assert.Equal(tt.want, d.Duration)
+
+			data, err := json.Marshal(d)
+
+			assert.NoError(err)
+			assert.Equal(tt.wantData, string(data))
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth scopes of lichess.org tokens
+const (
+	ScopePreferenceRead  = "preference:read"
+	ScopePreferenceWrite = "preference:write"
+	ScopeEmailRead       = "email:read"
+	ScopeChallengeRead   = "challenge:read"
+	ScopeChallengeWrite  = "challenge:write"
+	ScopeChallengeBulk   = "challenge:bulk"
+	ScopeStudyRead       = "study:read"
+	ScopeStudyWrite      = "study:write"
+	ScopeTournamentWrite = "tournament:write"
+	ScopeRacerWrite      = "racer:write"
+	ScopePuzzleRead      = "puzzle:read"
+	ScopePuzzleWrite     = "puzzle:write"
+	ScopeTeamRead        = "team:read"
+	ScopeTeamWrite       = "team:write"
+	ScopeTeamLead        = "team:lead"
+	ScopeFollowRead      = "follow:read"
+	ScopeFollowWrite     = "follow:write"
+	ScopeMsgWrite        = "msg:write"
+	ScopeBoardPlay       = "board:play"
+	ScopeBotPlay         = "bot:play"
+	ScopeEngineRead      = "engine:read"
+	ScopeEngineWrite     = "engine:write"
+)
+
+// tokenKey is context key for per-call token override
+type tokenKey struct{}
+
+// WithToken returns context that makes API calls use given token
+// instead of Config.Token. Empty token makes calls anonymous
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// WithAnonymous returns context that makes API calls without token
+func WithAnonymous(ctx context.Context) context.Context {
+	return WithToken(ctx, "")
+}
+
+// tokenFor returns token that should be used for call with ctx
+func (l *LichessAPI) tokenFor(ctx context.Context) string {
+	if token, ok := ctx.Value(tokenKey{}).(string); ok {
+		return token
+	}
+
+	return l.token
+}
+
+// TokenInfo stores token owner and granted scopes
+type TokenInfo struct {
+	UserID  string
+	Scopes  []string
+	Expires Timestamp // Zero if token does not expire
+}
+
+// UnmarshalJSON for TokenInfo struct
+func (t *TokenInfo) UnmarshalJSON(data []byte) error {
+	type tokenInfo struct {
+		UserID  string    `json:"userId"`
+		Scopes  string    `json:"scopes"`
+		Expires Timestamp `json:"expires"`
+	}
+
+	var v tokenInfo
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	t.UserID = v.UserID
+	t.Expires = v.Expires
+	t.Scopes = nil
+	for _, scope := range strings.Split(v.Scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			t.Scopes = append(t.Scopes, scope)
+		}
+	}
+
+	return nil
+}
+
+// HasScope reports whether token is granted scope
+func (t *TokenInfo) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TestTokens returns information about given tokens.
+// Tokens unknown to lichess.org or expired are mapped to nil
+func (l *LichessAPI) TestTokens(ctx context.Context, tokens ...string) (map[string]*TokenInfo, error) {
+	result := make(map[string]*TokenInfo)
+
+	if len(tokens) == 0 {
+		return result, nil
+	}
+	if len(tokens) > 1000 {
+		return result, errors.New("Too many requested tokens, max is 1000")
+	}
+
+	params := &reqParams{
+		name:        "tokenTest",
+		requestType: http.MethodPost,
+		endpoint:    l.endpoint.tokenTest,
+		data:        []byte(strings.Join(tokens, ",")),
+	}
+
+	resp, err := l.request(ctx, params)
+	if err != nil {
+		return result, err
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// scopeCache stores scopes of tokens used by client
+type scopeCache struct {
+	enabled bool
+	mu      sync.Mutex
+	tokens  map[string]*TokenInfo
+}
+
+func newScopeCache(enabled bool) *scopeCache {
+	return &scopeCache{
+		enabled: enabled,
+		tokens:  make(map[string]*TokenInfo),
+	}
+}
+
+// tokenInfo returns cached token info, requesting it on first use
+// and after cached token expired. Returns nil if token is unknown or expired,
+// such tokens are not cached
+func (l *LichessAPI) tokenInfo(ctx context.Context, token string) (*TokenInfo, error) {
+	l.scopes.mu.Lock()
+	info, ok := l.scopes.tokens[token]
+	if ok && !info.Expires.IsZero() && !time.Now().Before(info.Expires.Time) {
+		delete(l.scopes.tokens, token)
+		ok = false
+	}
+	l.scopes.mu.Unlock()
+
+	if ok {
+		return info, nil
+	}
+
+	infos, err := l.TestTokens(WithAnonymous(ctx), token)
+	if err != nil {
+		return nil, err
+	}
+
+	info = infos[token]
+	if info == nil {
+		return nil, nil
+	}
+
+	l.scopes.mu.Lock()
+	l.scopes.tokens[token] = info
+	l.scopes.mu.Unlock()
+
+	return info, nil
+}
+
+// checkScopes verifies that token has scopes required by request.
+// Unknown or expired token results in APIError with 401 status.
+// Does nothing unless Config.CheckScopes is set
+func (l *LichessAPI) checkScopes(ctx context.Context, token string, par *reqParams) error {
+	if !l.scopes.enabled || len(par.scopes) == 0 {
+		return nil
+	}
+
+	var info *TokenInfo
+	if token != "" {
+		var err error
+		info, err = l.tokenInfo(ctx, token)
+		if err != nil {
+			return err
+		}
+
+		if info == nil {
+			return &APIError{
+				StatusCode: http.StatusUnauthorized,
+				Message:    "token is unknown or expired",
+				Endpoint:   par.name,
+				URL:        par.endpoint,
+			}
+		}
+	}
+
+	var missing []string
+	for _, scope := range par.scopes {
+		if info == nil || !info.HasScope(scope) {
+			missing = append(missing, scope)
+		}
+	}
+
+	if len(missing) != 0 {
+		return &MissingScopeError{
+			Scopes:   missing,
+			Endpoint: par.name,
+			URL:      par.endpoint,
+		}
+	}
+
+	return nil
+}
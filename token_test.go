@@ -0,0 +1,208 @@
+package lichess
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TestTokens(t *testing.T) {
+	assert := assert.New(t)
+
+	type params struct {
+		requestType string
+		requestBody string
+		response    string
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		params  params
+		want    map[string]*TokenInfo
+		wantErr error
+	}{
+		{
+			name: "Test tokens",
+			args: []string{"lip_valid", "lip_revoked"},
+			params: params{
+				requestType: http.MethodPost,
+				requestBody: "lip_valid,lip_revoked",
+				response: `{
+					"lip_valid": {
+						"userId": "georges",
+						"scopes": "email:read,preference:read",
+						"expires": 1760000000000
+					},
+					"lip_revoked": null
+				}`,
+			},
+			want: map[string]*TokenInfo{
+				"lip_valid": {
+					UserID:  "georges",
+					Scopes:  []string{ScopeEmailRead, ScopePreferenceRead},
+					Expires: Timestamp{unixMilli(1760000000000)},
+				},
+				"lip_revoked": nil,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(tt.params.requestType, req.Method)
+
+				body, _ := ioutil.ReadAll(req.Body)
+				assert.Equal(tt.params.requestBody, string(body))
+
+				rw.Write([]byte(tt.params.response))
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:  "",
+				Client: server.Client(),
+			})
+			lapi.endpoint.tokenTest = server.URL
+
+			infos, err := lapi.TestTokens(context.Background(), tt.args...)
+
+			assert.Equal(tt.want, infos)
+			assert.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func Test_CheckScopes(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name          string
+		token         string
+		checkScopes   bool
+		wantEmail     string
+		wantScopeErr  bool
+		wantUnauth    bool
+		wantEmailCall bool
+		wantTokenCall int
+	}{
+		{
+			name:          "Token has scope",
+			token:         "lip_email",
+			checkScopes:   true,
+			wantEmail:     "georges@example.org",
+			wantEmailCall: true,
+			wantTokenCall: 1,
+		},
+		{
+			name:          "Token misses scope",
+			token:         "lip_prefs",
+			checkScopes:   true,
+			wantScopeErr:  true,
+			wantEmailCall: false,
+			wantTokenCall: 1,
+		},
+		{
+			name:          "Unknown token is not cached",
+			token:         "lip_unknown",
+			checkScopes:   true,
+			wantUnauth:    true,
+			wantEmailCall: false,
+			wantTokenCall: 2,
+		},
+		{
+			name:          "Checks disabled",
+			token:         "lip_prefs",
+			checkScopes:   false,
+			wantEmail:     "georges@example.org",
+			wantEmailCall: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenCalls := 0
+			emailCalls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/api/token/test":
+					tokenCalls++
+					rw.Write([]byte(`{
+						"lip_email": {"userId": "georges", "scopes": "email:read"},
+						"lip_prefs": {"userId": "georges", "scopes": "preference:read"},
+						"lip_unknown": null
+					}`))
+				case "/api/account/email":
+					emailCalls++
+					rw.Write([]byte(`{"email": "georges@example.org"}`))
+				}
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:       tt.token,
+				Client:      server.Client(),
+				Host:        server.URL,
+				CheckScopes: tt.checkScopes,
+			})
+
+			for i := 0; i < 2; i++ {
+				email, err := lapi.GetMyEmail(context.Background())
+
+				assert.Equal(tt.wantEmail, email)
+				assert.Equal(tt.wantScopeErr, IsMissingScope(err))
+				if scopeErr, ok := err.(*MissingScopeError); ok {
+					assert.Equal("accountEmail", scopeErr.Endpoint)
+					assert.Equal(server.URL+"/api/account/email", scopeErr.URL)
+				}
+				assert.Equal(tt.wantUnauth, IsUnauthorized(err))
+			}
+
+			assert.Equal(tt.wantTokenCall, tokenCalls)
+			assert.Equal(tt.wantEmailCall, emailCalls != 0)
+		})
+	}
+}
+
+func Test_CheckScopesExpiredToken(t *testing.T) {
+	assert := assert.New(t)
+
+	tokenCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/token/test":
+			tokenCalls++
+			expires := time.Now().Add(50*time.Millisecond).UnixNano() / int64(time.Millisecond)
+			fmt.Fprintf(rw, `{"lip_email": {"userId": "georges", "scopes": "email:read", "expires": %d}}`, expires)
+		case "/api/account/email":
+			rw.Write([]byte(`{"email": "georges@example.org"}`))
+		}
+	}))
+	defer server.Close()
+
+	lapi := NewLichessAPI(Config{
+		Token:       "lip_email",
+		Client:      server.Client(),
+		Host:        server.URL,
+		CheckScopes: true,
+	})
+
+	_, err := lapi.GetMyEmail(context.Background())
+	assert.NoError(err)
+	_, err = lapi.GetMyEmail(context.Background())
+	assert.NoError(err)
+	assert.Equal(1, tokenCalls)
+
+	time.Sleep(60 * time.Millisecond)
+
+	_, err = lapi.GetMyEmail(context.Background())
+	assert.NoError(err)
+	assert.Equal(2, tokenCalls)
+}
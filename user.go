@@ -1,17 +1,15 @@
-package main
+package lichess
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-// DailyRating stores rating during ont day
+// DailyRating stores rating during ont day.
+// Month is 0-based as sent by lichess.org, use Time for conversion
 type DailyRating struct {
 	Year   int
 	Month  int
@@ -19,19 +17,6 @@ type DailyRating struct {
 	Rating int
 }
 
-// Activity stores user activity on lichess.org
-type Activity struct {
-	Interval            Interval                `json:"interval"`
-	Games               map[string]GameActivity `json:"games"`
-	Puzzles             GameActivity            `json:"puzzles"`
-	Tournaments         TournamentActivity      `json:"tournaments"`
-	Practices           []Practice              `json:"practice"`
-	CorrespondenceMoves CorrespondenceMoves     `json:"correspondenceMoves"`
-	CorrespondenceEnds  CorrespondenceEnds      `json:"correspondenceEnds"`
-	Teams               []TeamActivity          `json:"teams"`
-	Posts               []Topic                 `json:"posts"`
-}
-
 // Topic represents forum topic
 type Topic struct {
 	TopicURL  string `json:"topicUrl"`
@@ -138,8 +123,8 @@ type ArenaTournament struct {
 	Name           string         `json:"name"`
 	NbPlayers      int            `json:"nbPlayers"`
 	Variant        Variant        `json:"variant"`
-	StartsAt       int64          `json:"startsAt"`
-	FinishesAt     int64          `json:"finishesAt"`
+	StartsAt       Timestamp      `json:"startsAt"`
+	FinishesAt     Timestamp      `json:"finishesAt"`
 	Status         int            `json:"status"`
 	Perf           TournamentPerf `json:"perf"`
 	SecondsToStart int            `json:"secondsToStart"`
@@ -205,110 +190,238 @@ type GameOpponent struct {
 
 // Interval represents time interval between activities
 type Interval struct {
-	Start int64 `json:"start"`
-	End   int64 `json:"end"`
+	Start Timestamp `json:"start"`
+	End   Timestamp `json:"end"`
 }
 
 // Clock represents chess clock
 type Clock struct {
-	Initial   int `json:"initial"`
-	Increment int `json:"increment"`
-	TotalTime int `json:"totalTime"`
+	Initial   Duration `json:"initial"`
+	Increment Duration `json:"increment"`
+	TotalTime Duration `json:"totalTime"`
+}
+
+// UserStatusOptions selects additional fields of UserStatus
+type UserStatusOptions struct {
+	// WithGameIDs fills UserStatus.PlayingID
+	WithGameIDs bool
+	// WithSignal fills UserStatus.Signal
+	WithSignal bool
+}
+
+// UserStatus stores user's online, playing and streaming status
+type UserStatus struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Patron    bool   `json:"patron"`
+	Online    bool   `json:"online"`
+	Playing   bool   `json:"playing"`
+	PlayingID string `json:"playingId"` // Only with UserStatusOptions.WithGameIDs
+	Streaming bool   `json:"streaming"`
+	Signal    int    `json:"signal"` // Connection quality from 1 to 4, only with UserStatusOptions.WithSignal
+}
+
+// LightUser stores basic user information
+type LightUser struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Title  string `json:"title"`
+	Patron bool   `json:"patron"`
+	Online bool   `json:"online"` // Only in autocomplete results
 }
 
-// UserCrosstable stores score of users matching against each other
+// User returns User with fields known from light user
+func (u LightUser) User() User {
+	return User{
+		ID:       u.ID,
+		Username: u.Name,
+		Title:    u.Title,
+		Patron:   u.Patron,
+		Online:   u.Online,
+	}
+}
+
+// AutocompleteOptions selects users returned by autocomplete
+type AutocompleteOptions struct {
+	// Friend returns followed users first, requires token
+	Friend bool
+}
+
+// minAutocompleteTerm is min length of autocompleted term
+const minAutocompleteTerm = 3
+
+// CrosstableOptions selects additional fields of UserCrosstable.
+// Lichess.org reports only total scores, so win, draw and loss
+// percentages are not available, see UserCrosstable.ScorePercentage
+type CrosstableOptions struct {
+	// Matchup fills UserCrosstable.Matchup with score of current match,
+	// it stays nil if users are not playing each other
+	Matchup bool
+}
+
+// UserCrosstable stores score of users matching against each other.
+// Users maps user id to score, a win counts 1 and a draw counts 0.5.
+// Score helpers report false if username is not in crosstable
 type UserCrosstable struct {
 	Users       map[string]float32 `json:"users"`
 	NumberGames int                `json:"nbGames"`
 	Matchup     *UserCrosstable    `json:"matchup"`
 }
 
-// GetUserStatus returns user's status from their ids
-func (l *LichessAPI) GetUserStatus(ids ...string) ([]User, error) {
-	var users []User
+// Score returns score of user, username is case-insensitive
+func (c *UserCrosstable) Score(username string) (float32, bool) {
+	score, ok := c.Users[strings.ToLower(username)]
+	return score, ok
+}
 
-	if len(ids) == 0 {
-		return users, nil
+// OpponentScore returns score of user's opponent
+func (c *UserCrosstable) OpponentScore(username string) (float32, bool) {
+	id := strings.ToLower(username)
+	if _, ok := c.Users[id]; !ok {
+		return 0, false
 	}
-	if len(ids) > 50 {
-		return users, errors.New("Too many requested profiles, max is 50")
+
+	for user, score := range c.Users {
+		if user != id {
+			return score, true
+		}
 	}
 
-	var sb strings.Builder
+	return 0, false
+}
 
-	for pos := range ids[:len(ids)-1] {
-		sb.WriteString(ids[pos])
-		sb.WriteString(",")
+// Margin returns how many points user is ahead of opponent, negative if behind
+func (c *UserCrosstable) Margin(username string) (float32, bool) {
+	score, ok := c.Score(username)
+	if !ok {
+		return 0, false
 	}
-	sb.WriteString(ids[len(ids)-1])
 
-	params := &reqParams{
-		requestType: http.MethodGet,
-		endpoint:    l.endpoint.userStatus,
-		query: map[string]string{
-			"ids": sb.String(),
-		},
+	opponent, ok := c.OpponentScore(username)
+	if !ok {
+		return 0, false
 	}
 
-	resp, err := l.request(params)
-	if err != nil {
-		return users, err
+	return score - opponent, true
+}
+
+// ScorePercentage returns share of points won by user in percents.
+// Crosstable has no separate win and draw counts, so draws count as half won.
+// Returns 0 if users have not played
+func (c *UserCrosstable) ScorePercentage(username string) (float64, bool) {
+	score, ok := c.Score(username)
+	if !ok {
+		return 0, false
 	}
 
-	defer resp.Body.Close()
+	if c.NumberGames == 0 {
+		return 0, true
+	}
 
-	err = json.NewDecoder(resp.Body).Decode(&users)
-	if err != nil {
-		return users, err
+	return float64(score) / float64(c.NumberGames) * 100, true
+}
+
+// GetUserStatus returns user's status from their ids.
+// Ids are requested in chunks lichess.org accepts, statuses are returned
+// in order of ids. Ids of closed or unknown accounts are returned as missing.
+// opts may be nil
+func (l *LichessAPI) GetUserStatus(ctx context.Context, opts *UserStatusOptions, ids ...string) ([]UserStatus, []string, error) {
+	var found []UserStatus
+
+	for _, chunk := range chunkIDs(ids, maxUserStatusIDs) {
+		statuses, err := l.getUserStatusChunk(ctx, opts, chunk)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		found = append(found, statuses...)
 	}
 
-	return users, nil
+	foundIDs := make([]string, len(found))
+	for i, status := range found {
+		foundIDs[i] = status.ID
+	}
+
+	order, missing := orderByIDs(ids, foundIDs)
+
+	statuses := make([]UserStatus, len(order))
+	for i, pos := range order {
+		statuses[i] = found[pos]
+	}
+
+	return statuses, missing, nil
 }
 
-// GetAllTop returns map with category and top 10 players
-func (l *LichessAPI) GetAllTop() (map[string][]User, error) {
-	var top map[string][]User
+// getUserStatusChunk requests status of at most maxUserStatusIDs users
+func (l *LichessAPI) getUserStatusChunk(ctx context.Context, opts *UserStatusOptions, ids []string) ([]UserStatus, error) {
+	var statuses []UserStatus
+
+	query := map[string]string{
+		"ids": strings.Join(ids, ","),
+	}
+
+	if opts != nil {
+		if opts.WithGameIDs {
+			query["withGameIds"] = "true"
+		}
+		if opts.WithSignal {
+			query["withSignal"] = "true"
+		}
+	}
 
 	params := &reqParams{
+		name:        "userStatus",
 		requestType: http.MethodGet,
-		endpoint:    l.endpoint.topAllPlayers,
-		header: map[string]string{
-			"Accept": "application/vnd.lichess.v3+json",
-		},
+		endpoint:    l.endpoint.userStatus,
+		query:       query,
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
-		return top, err
+		return statuses, err
 	}
 
 	defer resp.Body.Close()
 
-	err = json.NewDecoder(resp.Body).Decode(&top)
+	err = json.NewDecoder(resp.Body).Decode(&statuses)
 	if err != nil {
-		return top, err
+		return statuses, err
 	}
 
-	return top, nil
+	return statuses, nil
+}
+
+// GameURL returns link to game with given id, e.g. UserStatus.PlayingID
+func (l *LichessAPI) GameURL(id string) string {
+	return l.endpoint.host + "/" + id
 }
 
-// GetTop returns top N users in specified game category
-func (l *LichessAPI) GetTop(category string, number int) ([]User, error) {
+// GetTop returns top N users in perf, N is at most 200
+func (l *LichessAPI) GetTop(ctx context.Context, perf PerfType, number int) ([]User, error) {
 	type users struct {
 		Users []User `json:"users"`
 	}
 
 	var top users
 
+	if !perf.HasLeaderboard() {
+		return nil, fmt.Errorf("Perf %q has no leaderboard", perf)
+	}
+	if number < 1 || number > maxTopPlayers {
+		return nil, fmt.Errorf("Invalid number of top players %d, must be from 1 to %d", number, maxTopPlayers)
+	}
+
 	params := &reqParams{
+		name:        "topPlayers",
 		requestType: http.MethodGet,
-		endpoint:    fmt.Sprintf(l.endpoint.topPlayers, number, category),
+		endpoint:    fmt.Sprintf(l.endpoint.topPlayers, number, perf),
 		header: map[string]string{
 			"Accept": "application/vnd.lichess.v3+json",
 		},
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return top.Users, err
 	}
@@ -324,15 +437,16 @@ func (l *LichessAPI) GetTop(category string, number int) ([]User, error) {
 }
 
 // GetUser returns public information about user with specified username
-func (l *LichessAPI) GetUser(username string) (*User, error) {
+func (l *LichessAPI) GetUser(ctx context.Context, username string) (*User, error) {
 	var user User
 
 	params := &reqParams{
+		name:        "userProfile",
 		requestType: http.MethodGet,
 		endpoint:    fmt.Sprintf(l.endpoint.userProfile, username),
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -348,8 +462,8 @@ func (l *LichessAPI) GetUser(username string) (*User, error) {
 }
 
 // GetUserRatingHistory returns rating history of given user.
-// Format: map "category" -> array of ratings
-func (l *LichessAPI) GetUserRatingHistory(username string) (map[string][]DailyRating, error) {
+// Format: map "category" -> array of ratings, see NewRatingSeries
+func (l *LichessAPI) GetUserRatingHistory(ctx context.Context, username string) (map[string][]DailyRating, error) {
 	type ratingHistory struct {
 		Name   string  `json:"name"`
 		Points [][]int `json:"points"`
@@ -358,11 +472,12 @@ func (l *LichessAPI) GetUserRatingHistory(username string) (map[string][]DailyRa
 	var history []ratingHistory
 
 	params := &reqParams{
+		name:        "userRatingHistory",
 		requestType: http.MethodGet,
 		endpoint:    fmt.Sprintf(l.endpoint.userRatingHistory, username),
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -389,15 +504,16 @@ func (l *LichessAPI) GetUserRatingHistory(username string) (map[string][]DailyRa
 }
 
 // GetUserActivity returns user activity
-func (l *LichessAPI) GetUserActivity(username string) ([]Activity, error) {
+func (l *LichessAPI) GetUserActivity(ctx context.Context, username string) ([]Activity, error) {
 	var activity []Activity
 
 	params := &reqParams{
+		name:        "userActivity",
 		requestType: http.MethodGet,
 		endpoint:    fmt.Sprintf(l.endpoint.userActivity, username),
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -412,32 +528,48 @@ func (l *LichessAPI) GetUserActivity(username string) ([]Activity, error) {
 	return activity, nil
 }
 
-// GetUsesByID returns users by their ids
-func (l *LichessAPI) GetUsesByID(ids ...string) ([]User, error) {
-	var users []User
+// GetUsesByID returns users by their ids.
+// Ids are requested in chunks lichess.org accepts, users are returned
+// in order of ids. Ids of closed or unknown accounts are returned as missing
+func (l *LichessAPI) GetUsesByID(ctx context.Context, ids ...string) ([]User, []string, error) {
+	var found []User
 
-	if len(ids) == 0 {
-		return users, nil
+	for _, chunk := range chunkIDs(ids, maxUsersByID) {
+		users, err := l.getUsersByIDChunk(ctx, chunk)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		found = append(found, users...)
 	}
-	if len(ids) > 300 {
-		return users, errors.New("Too many requested profiles, max is 300")
+
+	foundIDs := make([]string, len(found))
+	for i, user := range found {
+		foundIDs[i] = user.ID
 	}
 
-	var sb strings.Builder
+	order, missing := orderByIDs(ids, foundIDs)
 
-	for pos := range ids[:len(ids)-1] {
-		sb.WriteString(ids[pos])
-		sb.WriteString(",")
+	users := make([]User, len(order))
+	for i, pos := range order {
+		users[i] = found[pos]
 	}
-	sb.WriteString(ids[len(ids)-1])
+
+	return users, missing, nil
+}
+
+// getUsersByIDChunk requests at most maxUsersByID users
+func (l *LichessAPI) getUsersByIDChunk(ctx context.Context, ids []string) ([]User, error) {
+	var users []User
 
 	params := &reqParams{
+		name:        "userData",
 		requestType: http.MethodPost,
 		endpoint:    l.endpoint.userData,
-		data:        []byte(sb.String()),
+		data:        []byte(strings.Join(ids, ",")),
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return users, err
 	}
@@ -452,89 +584,104 @@ func (l *LichessAPI) GetUsesByID(ids ...string) ([]User, error) {
 	return users, nil
 }
 
-// GetTeamMembers returns team members.
-// Use channel to get streamed values.
-// Call returned function to stop receiving
-func (l *LichessAPI) GetTeamMembers(id string) (chan User, func(), error) {
-	users := make(chan User, 10)
-	finishContext, cancel := context.WithCancel(context.Background())
+// AutocompletePlayers returns ids of users whose username starts with term.
+// Term must have at least 3 characters. opts may be nil
+func (l *LichessAPI) AutocompletePlayers(ctx context.Context, term string, opts *AutocompleteOptions) ([]string, error) {
+	var ids []string
 
-	params := &reqParams{
-		requestType: http.MethodGet,
-		endpoint:    fmt.Sprintf(l.endpoint.teamMembers, id),
+	params, err := l.autocompleteParams(term, opts, false)
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
-		return nil, cancel, err
-	}
-
-	reader := bufio.NewReader(resp.Body)
-
-	go func() {
-		defer resp.Body.Close()
-
-		for {
-			select {
-			case <-finishContext.Done():
-				return
-			default:
-				message, err := reader.ReadBytes('\n')
-				if err != nil {
-					close(users)
-					return
-				}
-				if len(message) != 0 {
-					var user User
-					err = json.NewDecoder(bytes.NewReader(message)).Decode(&user)
-					if err != nil {
-						close(users)
-						return
-					}
-
-					users <- user
-				}
-			}
-		}
-	}()
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&ids)
+	if err != nil {
+		return nil, err
+	}
 
-	return users, cancel, nil
+	return ids, nil
 }
 
-// GetLiveStreamers returs current live streaming users
-func (l *LichessAPI) GetLiveStreamers() ([]User, error) {
-	var users []User
+// AutocompleteUsers returns users whose username starts with term.
+// Term must have at least 3 characters. opts may be nil
+func (l *LichessAPI) AutocompleteUsers(ctx context.Context, term string, opts *AutocompleteOptions) ([]LightUser, error) {
+	type users struct {
+		Result []LightUser `json:"result"`
+	}
 
-	params := &reqParams{
-		requestType: http.MethodGet,
-		endpoint:    l.endpoint.userLiveStreaming,
+	var result users
+
+	params, err := l.autocompleteParams(term, opts, true)
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	err = json.NewDecoder(resp.Body).Decode(&users)
+	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
 		return nil, err
 	}
 
-	return users, nil
+	return result.Result, nil
 }
 
-// GetCrosstable returs crosstable of given users
-func (l *LichessAPI) GetCrosstable(usernameA, usernameB string) (*UserCrosstable, error) {
+// autocompleteParams validates term and builds autocomplete request
+func (l *LichessAPI) autocompleteParams(term string, opts *AutocompleteOptions, object bool) (*reqParams, error) {
+	term = strings.TrimSpace(term)
+	if len([]rune(term)) < minAutocompleteTerm {
+		return nil, fmt.Errorf("Autocomplete term %q is too short, min length is %d", term, minAutocompleteTerm)
+	}
+
+	query := map[string]string{
+		"term": term,
+	}
+
+	if object {
+		query["object"] = "true"
+	}
+	if opts != nil && opts.Friend {
+		query["friend"] = "true"
+	}
+
+	return &reqParams{
+		name:        "playerAutocomplete",
+		requestType: http.MethodGet,
+		endpoint:    l.endpoint.playerAutocomplete,
+		query:       query,
+	}, nil
+}
+
+// GetCrosstable returs crosstable of given users.
+// opts may be nil
+func (l *LichessAPI) GetCrosstable(ctx context.Context, usernameA, usernameB string, opts *CrosstableOptions) (*UserCrosstable, error) {
 	var result UserCrosstable
 
+	query := map[string]string{}
+	if opts != nil && opts.Matchup {
+		query["matchup"] = "true"
+	}
+
 	params := &reqParams{
+		name:        "userCrosstable",
 		requestType: http.MethodGet,
 		endpoint:    fmt.Sprintf(l.endpoint.userCrosstable, usernameA, usernameB),
+		query:       query,
 	}
 
-	resp, err := l.request(params)
+	resp, err := l.request(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
-package main
+package lichess
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -13,19 +14,20 @@ func Test_GetUserStatus(t *testing.T) {
 	assert := assert.New(t)
 
 	type args struct {
-		IDs []string
+		IDs     []string
+		Options *UserStatusOptions
 	}
 	type params struct {
 		requestType string
 		requestBody string
 		response    string
-		query       string
+		query       map[string]string
 	}
 	tests := []struct {
 		name    string
 		args    args
 		params  params
-		want    []User
+		want    []UserStatus
 		wantErr error
 	}{
 		{
@@ -34,134 +36,85 @@ func Test_GetUserStatus(t *testing.T) {
 				IDs: []string{"user1", "user2"},
 			},
 			params: params{
-				query:       "user1,user2",
+				query: map[string]string{
+					"ids": "user1,user2",
+				},
 				requestType: http.MethodGet,
 				requestBody: "",
 				response: `[{
 					"id": "user1",
-					"username": "user1",
+					"name": "User1",
 					"online": true
 				  },
 				  {
 					"id": "user2",
-					"username": "user2",
+					"name": "user2",
 					"online": true
 				  }]`,
 			},
-			want: []User{
+			want: []UserStatus{
 				{
-					ID:       "user1",
-					Username: "user1",
-					Online:   true,
+					ID:     "user1",
+					Name:   "User1",
+					Online: true,
 				},
 				{
-					ID:       "user2",
-					Username: "user2",
-					Online:   true,
+					ID:     "user2",
+					Name:   "user2",
+					Online: true,
 				},
 			},
 			wantErr: nil,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-				assert.Equal(tt.params.requestType, req.Method)
-
-				body, _ := ioutil.ReadAll(req.Body)
-				assert.Equal(tt.params.requestBody, string(body))
-
-				keys, ok := req.URL.Query()["ids"]
-				assert.True(ok)
-				assert.Equal(tt.params.query, keys[0])
-
-				rw.Write([]byte(tt.params.response))
-			}))
-
-			lapi := NewLichessAPI(Config{
-				Token:  "",
-				Client: server.Client(),
-			})
-			lapi.endpoint.userStatus = server.URL
-
-			users, err := lapi.GetUserStatus(tt.args.IDs...)
-
-			assert.Equal(tt.want, users)
-			assert.Equal(tt.wantErr, err)
-		})
-	}
-}
-
-func Test_GetAllTop(t *testing.T) {
-	assert := assert.New(t)
-
-	type params struct {
-		requestType string
-		requestBody string
-		response    string
-		query       string
-	}
-	tests := []struct {
-		name    string
-		params  params
-		want    map[string][]User
-		wantErr error
-	}{
 		{
-			name: "Get all top",
+			name: "Get user status with game ids and signal",
+			args: args{
+				IDs: []string{"user1", "user2"},
+				Options: &UserStatusOptions{
+					WithGameIDs: true,
+					WithSignal:  true,
+				},
+			},
 			params: params{
+				query: map[string]string{
+					"ids":         "user1,user2",
+					"withGameIds": "true",
+					"withSignal":  "true",
+				},
 				requestType: http.MethodGet,
 				requestBody: "",
-				response: `{
-					"bullet": [
-						{
-							"id": "bahadirozen",
-							"username": "BahadirOzen"
-						},
-						{
-							"id": "penguingim1",
-							"username": "penguingim1"
-						}
-					],
-					"blitz": [],
-					"rapid": [],
-					"classical": [],
-					"ultraBullet": [],
-					"chess960": [],
-					"crazyhouse": [],
-					"antichess": [],
-					"atomic": [],
-					"horde": [],
-					"kingOfTheHill": [],
-					"racingKings": [],
-					"threeCheck": []
-				
-				}`,
+				response: `[{
+					"id": "user1",
+					"name": "User1",
+					"title": "GM",
+					"online": true,
+					"playing": true,
+					"playingId": "yqfLYJ5E",
+					"streaming": true,
+					"signal": 4
+				  },
+				  {
+					"id": "user2",
+					"name": "user2",
+					"patron": true
+				  }]`,
 			},
-			want: map[string][]User{
-				"bullet": {
-					{
-						ID:       "bahadirozen",
-						Username: "BahadirOzen",
-					},
-					{
-						ID:       "penguingim1",
-						Username: "penguingim1",
-					},
+			want: []UserStatus{
+				{
+					ID:        "user1",
+					Name:      "User1",
+					Title:     "GM",
+					Online:    true,
+					Playing:   true,
+					PlayingID: "yqfLYJ5E",
+					Streaming: true,
+					Signal:    4,
+				},
+				{
+					ID:     "user2",
+					Name:   "user2",
+					Patron: true,
 				},
-				"blitz":         {},
-				"rapid":         {},
-				"classical":     {},
-				"ultraBullet":   {},
-				"chess960":      {},
-				"crazyhouse":    {},
-				"antichess":     {},
-				"atomic":        {},
-				"horde":         {},
-				"kingOfTheHill": {},
-				"racingKings":   {},
-				"threeCheck":    {},
 			},
 			wantErr: nil,
 		},
@@ -175,6 +128,12 @@ func Test_GetAllTop(t *testing.T) {
 				body, _ := ioutil.ReadAll(req.Body)
 				assert.Equal(tt.params.requestBody, string(body))
 
+				query := make(map[string]string)
+				for k := range req.URL.Query() {
+					query[k] = req.URL.Query().Get(k)
+				}
+				assert.Equal(tt.params.query, query)
+
 				rw.Write([]byte(tt.params.response))
 			}))
 
@@ -182,11 +141,12 @@ func Test_GetAllTop(t *testing.T) {
 				Token:  "",
 				Client: server.Client(),
 			})
-			lapi.endpoint.topAllPlayers = server.URL
+			lapi.endpoint.userStatus = server.URL
 
-			users, err := lapi.GetAllTop()
+			statuses, missing, err := lapi.GetUserStatus(context.Background(), tt.args.Options, tt.args.IDs...)
 
-			assert.Equal(tt.want, users)
+			assert.Equal(tt.want, statuses)
+			assert.Empty(missing)
 			assert.Equal(tt.wantErr, err)
 		})
 	}
@@ -196,8 +156,8 @@ func Test_GetTop(t *testing.T) {
 	assert := assert.New(t)
 
 	type args struct {
-		Category string
-		Number   int
+		Perf   PerfType
+		Number int
 	}
 	type params struct {
 		requestType string
@@ -215,8 +175,8 @@ func Test_GetTop(t *testing.T) {
 		{
 			name: "Get top in category",
 			args: args{
-				Category: "bullet",
-				Number:   3,
+				Perf:   PerfBullet,
+				Number: 3,
 			},
 			params: params{
 				query:       "user1,user2",
@@ -273,7 +233,7 @@ func Test_GetTop(t *testing.T) {
 			})
 			lapi.endpoint.topPlayers = server.URL + "/%d/%s"
 
-			users, err := lapi.GetTop(tt.args.Category, tt.args.Number)
+			users, err := lapi.GetTop(context.Background(), tt.args.Perf, tt.args.Number)
 
 			assert.Equal(tt.want, users)
 			assert.Equal(tt.wantErr, err)
@@ -281,6 +241,57 @@ func Test_GetTop(t *testing.T) {
 	}
 }
 
+func Test_GetTopValidation(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name   string
+		perf   PerfType
+		number int
+	}{
+		{
+			name:   "Unknown perf",
+			perf:   PerfType("blits"),
+			number: 10,
+		},
+		{
+			name:   "Perf without leaderboard",
+			perf:   PerfCorrespondence,
+			number: 10,
+		},
+		{
+			name:   "Too many players",
+			perf:   PerfBlitz,
+			number: 201,
+		},
+		{
+			name:   "No players",
+			perf:   PerfBlitz,
+			number: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				t.Error("Request must not be sent")
+			}))
+			defer server.Close()
+
+			lapi := NewLichessAPI(Config{
+				Token:  "",
+				Client: server.Client(),
+			})
+			lapi.endpoint.topPlayers = server.URL + "/%d/%s"
+
+			users, err := lapi.GetTop(context.Background(), tt.perf, tt.number)
+
+			assert.Nil(users)
+			assert.Error(err)
+		})
+	}
+}
+
 func Test_GetUser(t *testing.T) {
 	assert := assert.New(t)
 
@@ -380,7 +391,7 @@ func Test_GetUser(t *testing.T) {
 				ID:       "georges",
 				Username: "Georges",
 				Online:   true,
-				Performances: map[string]Performance{
+				Performances: map[PerfType]Performance{
 					"blitz": {
 						Games:           2945,
 						Rating:          1609,
@@ -396,7 +407,7 @@ func Test_GetUser(t *testing.T) {
 						Prov:            true,
 					},
 				},
-				CreatedAt:    1290415680000,
+				CreatedAt:    Timestamp{unixMilli(1290415680000)},
 				Disabled:     false,
 				TOSViolation: false,
 				Booster:      false,
@@ -411,7 +422,7 @@ func Test_GetUser(t *testing.T) {
 					EcfRating:  1500,
 					Links:      "github.com/ornicar\r\ntwitter.com/ornicar",
 				},
-				SeenAt: 1522636452014,
+				SeenAt: Timestamp{unixMilli(1522636452014)},
 				Patron: true,
 				PlayTime: PlayTime{
 					Total: 3296897,
@@ -466,7 +477,7 @@ func Test_GetUser(t *testing.T) {
 			})
 			lapi.endpoint.userProfile = server.URL + "/%s"
 
-			user, err := lapi.GetUser(tt.args.Username)
+			user, err := lapi.GetUser(context.Background(), tt.args.Username)
 
 			assert.Equal(tt.want, *user)
 			assert.Equal(tt.wantErr, err)
@@ -554,7 +565,7 @@ func Test_GetUserRatingHistory(t *testing.T) {
 			})
 			lapi.endpoint.userRatingHistory = server.URL + "/%s"
 
-			users, err := lapi.GetUserRatingHistory(tt.args.Username)
+			users, err := lapi.GetUserRatingHistory(context.Background(), tt.args.Username)
 
 			assert.Equal(tt.want, users)
 			assert.Equal(tt.wantErr, err)
@@ -631,51 +642,88 @@ func Test_GetUsesByID(t *testing.T) {
 			})
 			lapi.endpoint.userData = server.URL
 
-			users, err := lapi.GetUsesByID(tt.args.IDs...)
+			users, missing, err := lapi.GetUsesByID(context.Background(), tt.args.IDs...)
 
 			assert.Equal(tt.want, users)
+			assert.Empty(missing)
 			assert.Equal(tt.wantErr, err)
 		})
 	}
 }
 
-func Test_GetTeamMembers(t *testing.T) {
+func Test_GetCrosstable(t *testing.T) {
 	assert := assert.New(t)
 
 	type args struct {
-		ID string
+		userA   string
+		userB   string
+		options *CrosstableOptions
 	}
 	type params struct {
 		requestType string
 		requestBody string
 		response    string
-		query       string
+		query       map[string]string
 	}
 	tests := []struct {
 		name    string
 		args    args
 		params  params
-		want    []User
+		want    UserCrosstable
 		wantErr error
 	}{
 		{
-			name: "Get team members",
-			args: args{
-				ID: "team",
+			name: "Get crosstable",
+			args: args{"userA", "userB", nil},
+			params: params{
+				requestType: http.MethodGet,
+				response: `{
+						"users": 
+						{
+							"neio": 201.5,
+							"thibault": 144.5
+						},
+						"nbGames": 346
+					}`,
 			},
+			want: UserCrosstable{
+				Users: map[string]float32{
+					"neio":     201.5,
+					"thibault": 144.5,
+				},
+				NumberGames: 346,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Get crosstable with matchup",
+			args: args{"userA", "userB", &CrosstableOptions{Matchup: true}},
 			params: params{
 				requestType: http.MethodGet,
-				response: "{\"id\": \"bahadirozen\", \"username\": \"BahadirOzen\"}\n" +
-					"{\"id\": \"penguingim1\", \"username\": \"penguingim1\"}\n",
+				query: map[string]string{
+					"matchup": "true",
+				},
+				response: `{
+						"users": {"neio": 201.5, "thibault": 144.5},
+						"nbGames": 346,
+						"matchup": {
+							"users": {"neio": 3, "thibault": 1},
+							"nbGames": 4
+						}
+					}`,
 			},
-			want: []User{
-				{
-					ID:       "bahadirozen",
-					Username: "BahadirOzen",
+			want: UserCrosstable{
+				Users: map[string]float32{
+					"neio":     201.5,
+					"thibault": 144.5,
 				},
-				{
-					ID:       "penguingim1",
-					Username: "penguingim1",
+				NumberGames: 346,
+				Matchup: &UserCrosstable{
+					Users: map[string]float32{
+						"neio":     3,
+						"thibault": 1,
+					},
+					NumberGames: 4,
 				},
 			},
 			wantErr: nil,
@@ -690,6 +738,12 @@ func Test_GetTeamMembers(t *testing.T) {
 				body, _ := ioutil.ReadAll(req.Body)
 				assert.Equal(tt.params.requestBody, string(body))
 
+				query := req.URL.Query()
+				assert.Len(query, len(tt.params.query))
+				for k, v := range tt.params.query {
+					assert.Equal(v, query.Get(k))
+				}
+
 				rw.Write([]byte(tt.params.response))
 			}))
 
@@ -697,162 +751,195 @@ func Test_GetTeamMembers(t *testing.T) {
 				Token:  "",
 				Client: server.Client(),
 			})
-			lapi.endpoint.teamMembers = server.URL + "/%s"
+			lapi.endpoint.userCrosstable = server.URL + "/%s/%s"
 
-			uchan, _, err := lapi.GetTeamMembers(tt.args.ID)
+			users, err := lapi.GetCrosstable(context.Background(), tt.args.userA, tt.args.userB, tt.args.options)
 
-			var users []User
+			assert.Equal(tt.want, *users)
+			assert.Equal(tt.wantErr, err)
 
-			for u := range uchan {
-				users = append(users, u)
-			}
+			users, err = lapi.GetCrosstable(context.Background(), tt.args.userB, tt.args.userA, tt.args.options)
 
-			assert.Equal(tt.want, users)
+			assert.Equal(tt.want, *users)
 			assert.Equal(tt.wantErr, err)
 		})
 	}
 }
 
-func Test_GetLiveStreamers(t *testing.T) {
+func Test_CrosstableScore(t *testing.T) {
 	assert := assert.New(t)
 
-	type params struct {
-		requestType string
-		requestBody string
-		response    string
-		query       string
+	crosstable := &UserCrosstable{
+		Users: map[string]float32{
+			"neio":     201.5,
+			"thibault": 144.5,
+		},
+		NumberGames: 346,
 	}
+
 	tests := []struct {
-		name    string
-		params  params
-		want    []User
-		wantErr error
+		name         string
+		crosstable   *UserCrosstable
+		username     string
+		wantOk       bool
+		wantScore    float32
+		wantOpponent float32
+		wantMargin   float32
+		wantPercent  float64
 	}{
 		{
-			name: "Get live streamers",
-			params: params{
-				requestType: http.MethodGet,
-				response: `[
-					{
-						"id": "bahadirozen",
-						"username": "BahadirOzen"
-					},
-					{
-						"id": "penguingim1",
-						"username": "penguingim1"
-					}
-				]`,
-			},
-			want: []User{
-				{
-					ID:       "bahadirozen",
-					Username: "BahadirOzen",
-				},
-				{
-					ID:       "penguingim1",
-					Username: "penguingim1",
-				},
-			},
-			wantErr: nil,
+			name:         "Leading user",
+			crosstable:   crosstable,
+			username:     "Neio",
+			wantOk:       true,
+			wantScore:    201.5,
+			wantOpponent: 144.5,
+			wantMargin:   57,
+			wantPercent:  58.24,
+		},
+		{
+			name:         "Trailing user",
+			crosstable:   crosstable,
+			username:     "thibault",
+			wantOk:       true,
+			wantScore:    144.5,
+			wantOpponent: 201.5,
+			wantMargin:   -57,
+			wantPercent:  41.76,
+		},
+		{
+			name:       "User not in crosstable",
+			crosstable: crosstable,
+			username:   "georges",
+			wantOk:     false,
+		},
+		{
+			name:       "Users have not played",
+			crosstable: &UserCrosstable{Users: map[string]float32{"neio": 0, "thibault": 0}},
+			username:   "neio",
+			wantOk:     true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-				assert.Equal(tt.params.requestType, req.Method)
-
-				body, _ := ioutil.ReadAll(req.Body)
-				assert.Equal(tt.params.requestBody, string(body))
+			score, ok := tt.crosstable.Score(tt.username)
+			assert.Equal(tt.wantOk, ok)
+			assert.Equal(tt.wantScore, score)
 
-				rw.Write([]byte(tt.params.response))
-			}))
-
-			lapi := NewLichessAPI(Config{
-				Token:  "",
-				Client: server.Client(),
-			})
-			lapi.endpoint.userLiveStreaming = server.URL
+			opponent, ok := tt.crosstable.OpponentScore(tt.username)
+			assert.Equal(tt.wantOk, ok)
+			assert.Equal(tt.wantOpponent, opponent)
 
-			users, err := lapi.GetLiveStreamers()
+			margin, ok := tt.crosstable.Margin(tt.username)
+			assert.Equal(tt.wantOk, ok)
+			assert.Equal(tt.wantMargin, margin)
 
-			assert.Equal(tt.want, users)
-			assert.Equal(tt.wantErr, err)
+			percent, ok := tt.crosstable.ScorePercentage(tt.username)
+			assert.Equal(tt.wantOk, ok)
+			assert.InDelta(tt.wantPercent, percent, 0.01)
 		})
 	}
 }
 
-func Test_GetCrosstable(t *testing.T) {
+func Test_Autocomplete(t *testing.T) {
 	assert := assert.New(t)
 
-	type args struct {
-		userA string
-		userB string
-	}
 	type params struct {
-		requestType string
-		requestBody string
-		response    string
-		query       string
+		query    map[string]string
+		response string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		params  params
-		want    UserCrosstable
-		wantErr error
+		name      string
+		term      string
+		options   *AutocompleteOptions
+		object    bool
+		params    params
+		wantIDs   []string
+		wantUsers []LightUser
+		wantErr   bool
 	}{
 		{
-			name: "Get crosstable",
-			args: args{"userA", "userB"},
+			name: "Autocomplete ids",
+			term: "thib",
 			params: params{
-				requestType: http.MethodGet,
-				response: `{
-						"users": 
-						{
-							"neio": 201.5,
-							"thibault": 144.5
-						},
-						"nbGames": 346
-					}`,
+				query: map[string]string{
+					"term": "thib",
+				},
+				response: `["thibault", "thibaut"]`,
 			},
-			want: UserCrosstable{
-				Users: map[string]float32{
-					"neio":     201.5,
-					"thibault": 144.5,
+			wantIDs: []string{"thibault", "thibaut"},
+		},
+		{
+			name:    "Autocomplete users of friends",
+			term:    "thib",
+			options: &AutocompleteOptions{Friend: true},
+			object:  true,
+			params: params{
+				query: map[string]string{
+					"term":   "thib",
+					"object": "true",
+					"friend": "true",
+				},
+				response: `{"result": [{"id": "thibault", "name": "thibault", "patron": true, "online": true}]}`,
+			},
+			wantUsers: []LightUser{
+				{
+					ID:     "thibault",
+					Name:   "thibault",
+					Patron: true,
+					Online: true,
 				},
-				NumberGames: 346,
 			},
-			wantErr: nil,
+		},
+		{
+			name:    "Term too short",
+			term:    " th ",
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-				assert.Equal(tt.params.requestType, req.Method)
+				assert.Equal(http.MethodGet, req.Method)
 
-				body, _ := ioutil.ReadAll(req.Body)
-				assert.Equal(tt.params.requestBody, string(body))
+				query := req.URL.Query()
+				assert.Len(query, len(tt.params.query))
+				for k, v := range tt.params.query {
+					assert.Equal(v, query.Get(k))
+				}
 
 				rw.Write([]byte(tt.params.response))
 			}))
+			defer server.Close()
 
 			lapi := NewLichessAPI(Config{
 				Token:  "",
 				Client: server.Client(),
 			})
-			lapi.endpoint.userCrosstable = server.URL + "/%s/%s"
+			lapi.endpoint.playerAutocomplete = server.URL
 
-			users, err := lapi.GetCrosstable(tt.args.userA, tt.args.userB)
+			if tt.object {
+				users, err := lapi.AutocompleteUsers(context.Background(), tt.term, tt.options)
 
-			assert.Equal(tt.want, *users)
-			assert.Equal(tt.wantErr, err)
+				assert.NoError(err)
+				assert.Equal(tt.wantUsers, users)
+				return
+			}
 
-			users, err = lapi.GetCrosstable(tt.args.userB, tt.args.userA)
+			ids, err := lapi.AutocompletePlayers(context.Background(), tt.term, tt.options)
 
-			assert.Equal(tt.want, *users)
-			assert.Equal(tt.wantErr, err)
+			assert.Equal(tt.wantErr, err != nil)
+			assert.Equal(tt.wantIDs, ids)
 		})
 	}
 }
+
+func Test_LightUserUser(t *testing.T) {
+	assert := assert.New(t)
+
+	user := LightUser{ID: "georges", Name: "Georges", Title: "NM", Online: true}.User()
+
+	assert.Equal(User{ID: "georges", Username: "Georges", Title: "NM", Online: true}, user)
+}